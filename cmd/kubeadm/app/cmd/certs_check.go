@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/certs/renewal"
+)
+
+type certsCheckFlags struct {
+	certDir        string
+	kubeconfigPath string
+	outputJSON     bool
+	watch          bool
+	checkInterval  time.Duration
+}
+
+// newCmdCertsCheck returns the cobra command for "kubeadm certs check".
+//
+// Unlike "kubeadm certs check-expiration", this command can also keep running
+// in --watch mode, updating a kubeadm_certificate_expiration_seconds metric
+// and posting Events once a certificate crosses into a warning/critical
+// threshold, so a node agent does not need to shell out to check expiration.
+// The metric is registered in this process's legacy registry; this command
+// does not serve it over HTTP.
+func newCmdCertsCheck(out io.Writer) *cobra.Command {
+	flags := &certsCheckFlags{
+		certDir:        kubeadmconstants.KubernetesDir + "/pki",
+		kubeconfigPath: kubeadmconstants.GetAdminKubeConfigPath(),
+		checkInterval:  time.Hour,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check expiration for the certificates managed by kubeadm",
+		Long: "Check the expiration date of the certificates and certificate authorities managed by\n" +
+			"kubeadm, classify each of them into an INFO/WARNING/CRITICAL/EXPIRED threshold, and print\n" +
+			"the result. When --watch is set, kubeadm keeps running, periodically re-checking\n" +
+			"expiration, updating the kubeadm_certificate_expiration_seconds metric and, if a\n" +
+			"kubeconfig is available, posting a CertificateExpiringSoon or CertificateExpired Event\n" +
+			"against the local node. `--watch` is the supported way to run this check as a\n" +
+			"long-running daemon, e.g. as the command of a sidecar container added to one of the\n" +
+			"control-plane static pod manifests, instead of a cron-style invocation.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCertsCheck(out, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.certDir, "cert-dir", flags.certDir, "The path where certificates are stored")
+	cmd.Flags().StringVar(&flags.kubeconfigPath, "kubeconfig", flags.kubeconfigPath, "The kubeconfig file to use when posting expiration Events; leave empty to skip Events")
+	cmd.Flags().BoolVar(&flags.outputJSON, "output-json", false, "Output the result as JSON instead of a human-readable table")
+	cmd.Flags().BoolVar(&flags.watch, "watch", false, "Keep running, periodically re-checking certificate expiration")
+	cmd.Flags().DurationVar(&flags.checkInterval, "check-interval", flags.checkInterval, "How often to re-check expiration when --watch is set")
+
+	return cmd
+}
+
+func runCertsCheck(out io.Writer, flags *certsCheckFlags) error {
+	cfg := &kubeadmapi.ClusterConfiguration{CertificatesDir: flags.certDir}
+
+	manager, err := renewal.NewManager(cfg, kubeadmconstants.KubernetesDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the certificate renewal manager")
+	}
+
+	if !flags.watch {
+		infos, err := manager.CheckExpiration(time.Now())
+		if err != nil {
+			return err
+		}
+		return printExpiration(out, infos, flags.outputJSON)
+	}
+
+	client, err := loadExpirationClient(flags.kubeconfigPath)
+	if err != nil {
+		fmt.Fprintf(out, "[certs] could not load a kubeconfig from %q, Events will not be posted: %v\n", flags.kubeconfigPath, err)
+	}
+
+	nodeName, err := os.Hostname()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine the local node name")
+	}
+
+	watcher := renewal.NewExpirationWatcher(manager, client, nodeName, flags.checkInterval)
+	watcher.Run(context.Background(), time.Now)
+	return nil
+}
+
+func loadExpirationClient(kubeconfigPath string) (kubernetes.Interface, error) {
+	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(restConfig)
+}
+
+func printExpiration(out io.Writer, infos []renewal.CertExpirationInfo, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(infos)
+	}
+
+	fmt.Fprintln(out, "CERTIFICATE\tEXPIRES\tSTATUS")
+	for _, info := range infos {
+		fmt.Fprintf(out, "%s\t%s\t%s\n", info.Name, info.NotAfter.Format(time.RFC3339), info.Threshold)
+	}
+	return nil
+}
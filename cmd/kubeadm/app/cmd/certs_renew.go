@@ -0,0 +1,270 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/certs/renewal"
+)
+
+type certsRenewFlags struct {
+	certDir           string
+	keyType           string
+	useAPI            bool
+	kubeconfigPath    string
+	signerName        string
+	timeout           time.Duration
+	maxConcurrent     int
+	renewalConfigPath string
+}
+
+// newCmdCertsRenewal returns the cobra command for "kubeadm certs renew".
+//
+// By default it re-signs a certificate with the local CA, preserving its
+// current key type. --key-type migrates it to a different key type instead;
+// --use-api renews it online through a CertificateSigningRequest against the
+// cluster instead of the local CA; --renewal-config selects the ACME backend
+// for specific certificates instead. KMS/HSM-backed renewal (see
+// renewal.KeyStorage) has no CLI flag because it requires a live signer
+// connection, not serializable config - wire it up with
+// Manager.SetKeyStorage from a custom controller embedding this package.
+func newCmdCertsRenewal(out io.Writer) *cobra.Command {
+	flags := &certsRenewFlags{
+		certDir:        kubeadmconstants.KubernetesDir + "/pki",
+		kubeconfigPath: kubeadmconstants.GetAdminKubeConfigPath(),
+		signerName:     "kubernetes.io/kube-apiserver-client-kubelet",
+		timeout:        5 * time.Minute,
+		maxConcurrent:  5,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "renew <certificate-name>|all",
+		Short: "Renew certificates for a Kubernetes cluster",
+		Long: "Renew one managed certificate, or all of them when \"all\" is given, using the local\n" +
+			"CA by default. Pass --key-type to migrate the certificate to a different key type during\n" +
+			"the renewal, --use-api to renew it online through the cluster's CertificateSigningRequest\n" +
+			"API instead, or --renewal-config to route specific certificates to the ACME renewal\n" +
+			"backend.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCertsRenew(out, flags, args[0])
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.certDir, "cert-dir", flags.certDir, "The path where certificates are stored")
+	cmd.Flags().StringVar(&flags.keyType, "key-type", "", "Migrate the certificate to this key type during renewal (one of: RSA, ECDSA-P256, ECDSA-P384, Ed25519); leave empty to preserve its current key type")
+	cmd.Flags().BoolVar(&flags.useAPI, "use-api", false, "Renew by submitting a CertificateSigningRequest to the cluster's certificates.k8s.io API instead of using the local CA")
+	cmd.Flags().StringVar(&flags.kubeconfigPath, "kubeconfig", flags.kubeconfigPath, "The kubeconfig file to use when --use-api is set")
+	cmd.Flags().StringVar(&flags.signerName, "csr-signer-name", flags.signerName, "The signerName to request on CertificateSigningRequests submitted with --use-api")
+	cmd.Flags().DurationVar(&flags.timeout, "use-api-timeout", flags.timeout, "How long to wait for a CertificateSigningRequest submitted with --use-api to be approved and issued")
+	cmd.Flags().IntVar(&flags.maxConcurrent, "use-api-concurrent", flags.maxConcurrent, "Maximum number of certificates to renew concurrently with --use-api when renewing \"all\"")
+	cmd.Flags().StringVar(&flags.renewalConfigPath, "renewal-config", "", "Path to a YAML file listing ACME renewal settings for specific certificates, used instead of the local CA for those certificates")
+
+	return cmd
+}
+
+func runCertsRenew(out io.Writer, flags *certsRenewFlags, arg string) error {
+	cfg := &kubeadmapi.ClusterConfiguration{CertificatesDir: flags.certDir}
+
+	manager, err := renewal.NewManager(cfg, kubeadmconstants.KubernetesDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the certificate renewal manager")
+	}
+
+	if flags.renewalConfigPath != "" {
+		policy, err := loadRenewalPolicy(flags.renewalConfigPath)
+		if err != nil {
+			return err
+		}
+		manager.SetRenewalPolicy(policy)
+	}
+
+	names, err := certNamesToRenew(manager, arg)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case flags.useAPI:
+		return renewAllViaAPI(out, manager, flags, names)
+	case flags.keyType != "":
+		return renewAllWithKeyType(out, manager, flags, names)
+	default:
+		return renewAllWithPolicy(out, manager, names)
+	}
+}
+
+// certNamesToRenew resolves the "<certificate-name>|all" positional argument
+// into the list of known certificate names it refers to.
+func certNamesToRenew(manager *renewal.Manager, arg string) ([]string, error) {
+	if arg != "all" {
+		if _, err := manager.CertificateExists(arg); err != nil {
+			return nil, err
+		}
+		return []string{arg}, nil
+	}
+
+	names := make([]string, 0, len(manager.Certificates()))
+	for _, handler := range manager.Certificates() {
+		names = append(names, handler.Name)
+	}
+	return names, nil
+}
+
+// renewAllWithPolicy renews names through RenewWithPolicy, which uses the
+// Manager's RenewalPolicy/KeyStorage backend for a certificate when one is
+// configured, falling back to the local CA otherwise.
+func renewAllWithPolicy(out io.Writer, manager *renewal.Manager, names []string) error {
+	for _, name := range names {
+		if _, err := manager.RenewWithPolicy(name); err != nil {
+			return errors.Wrapf(err, "failed to renew %s", name)
+		}
+		fmt.Fprintf(out, "[certs] %s renewed\n", name)
+	}
+	return nil
+}
+
+// renewAllWithKeyType migrates each of names to flags.keyType during renewal.
+func renewAllWithKeyType(out io.Writer, manager *renewal.Manager, flags *certsRenewFlags, names []string) error {
+	kt, err := parseEncryptionAlgorithmType(flags.keyType)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if _, err := manager.RenewWithKeyType(name, kt); err != nil {
+			return errors.Wrapf(err, "failed to renew %s", name)
+		}
+		fmt.Fprintf(out, "[certs] %s renewed, migrated to key type %s\n", name, kt)
+	}
+	return nil
+}
+
+// parseEncryptionAlgorithmType validates and normalizes the --key-type flag
+// value against the known kubeadmapi.EncryptionAlgorithmType constants,
+// accepting any case (e.g. "ecdsa-p256") so the flag isn't picky about it.
+func parseEncryptionAlgorithmType(s string) (kubeadmapi.EncryptionAlgorithmType, error) {
+	switch strings.ToUpper(s) {
+	case strings.ToUpper(string(kubeadmapi.EncryptionAlgorithmRSA)):
+		return kubeadmapi.EncryptionAlgorithmRSA, nil
+	case strings.ToUpper(string(kubeadmapi.EncryptionAlgorithmECDSAP256)):
+		return kubeadmapi.EncryptionAlgorithmECDSAP256, nil
+	case strings.ToUpper(string(kubeadmapi.EncryptionAlgorithmECDSAP384)):
+		return kubeadmapi.EncryptionAlgorithmECDSAP384, nil
+	case strings.ToUpper(string(kubeadmapi.EncryptionAlgorithmEd25519)):
+		return kubeadmapi.EncryptionAlgorithmEd25519, nil
+	default:
+		return "", errors.Errorf("invalid --key-type %q: must be one of RSA, ECDSA-P256, ECDSA-P384, Ed25519", s)
+	}
+}
+
+// renewAllViaAPI renews names by submitting CertificateSigningRequests
+// against the cluster, bounding concurrency to flags.maxConcurrent when
+// renewing more than one certificate.
+func renewAllViaAPI(out io.Writer, manager *renewal.Manager, flags *certsRenewFlags, names []string) error {
+	restConfig, err := clientcmd.BuildConfigFromFlags("", flags.kubeconfigPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load the kubeconfig at %s", flags.kubeconfigPath)
+	}
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to build a client from the kubeconfig")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), flags.timeout)
+	defer cancel()
+
+	controller := renewal.NewCSRController(client, flags.signerName)
+
+	if len(names) == 1 {
+		if _, err := manager.RenewViaAPI(ctx, names[0], controller); err != nil {
+			return errors.Wrapf(err, "failed to renew %s", names[0])
+		}
+		fmt.Fprintf(out, "[certs] %s renewed\n", names[0])
+		return nil
+	}
+
+	renewed, err := manager.RenewAllViaAPI(ctx, controller, flags.maxConcurrent)
+	for _, name := range renewed {
+		fmt.Fprintf(out, "[certs] %s renewed\n", name)
+	}
+	return err
+}
+
+// renewalConfigFile is the on-disk shape of --renewal-config: settings for
+// the ACME backend, keyed by certificate name. KMS/HSM-backed renewal
+// (renewal.KeyStorage) is deliberately not part of this file - it requires a
+// live signer connection, which belongs in code wiring up Manager.SetKeyStorage,
+// not in a config file passed on the command line.
+type renewalConfigFile struct {
+	ACME map[string]acmeRenewalConfigFile `json:"acme,omitempty"`
+}
+
+type acmeRenewalConfigFile struct {
+	DirectoryURL   string `json:"directoryURL"`
+	AccountKeyPath string `json:"accountKeyPath"`
+	ChallengeType  string `json:"challengeType"`
+	Contact        string `json:"contact,omitempty"`
+	EABKeyID       string `json:"eabKeyID,omitempty"`
+	EABKey         string `json:"eabKey,omitempty"`
+}
+
+// loadRenewalPolicy reads a --renewal-config file into a renewal.RenewalPolicy.
+// The returned policy has no ACMEResponder: challenge publishing is
+// environment-specific and this command does not set one, so renewing a
+// certificate listed in the file through this command returns the ACME
+// backend's missing-responder error. Code embedding this package (e.g. a
+// static pod sidecar) sets the responder on the policy itself.
+func loadRenewalPolicy(path string) (*renewal.RenewalPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read --renewal-config %s", path)
+	}
+
+	var parsed renewalConfigFile
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse --renewal-config %s", path)
+	}
+
+	policy := &renewal.RenewalPolicy{ACME: map[string]renewal.ACMERenewalConfig{}}
+	for name, c := range parsed.ACME {
+		policy.ACME[name] = renewal.ACMERenewalConfig{
+			DirectoryURL:   c.DirectoryURL,
+			AccountKeyPath: c.AccountKeyPath,
+			ChallengeType:  renewal.ACMEChallengeType(c.ChallengeType),
+			Contact:        c.Contact,
+			EABKeyID:       c.EABKeyID,
+			EABKey:         c.EABKey,
+		}
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"os"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/acme"
+
+	certutil "k8s.io/client-go/util/cert"
+	clientkeyutil "k8s.io/client-go/util/keyutil"
+)
+
+// ACMEChallengeType selects which ACME challenge kubeadm solves to prove
+// ownership of the identifiers on a certificate.
+type ACMEChallengeType string
+
+const (
+	// ACMEChallengeHTTP01 solves the challenge by serving a token over HTTP.
+	ACMEChallengeHTTP01 ACMEChallengeType = "http-01"
+	// ACMEChallengeDNS01 solves the challenge by publishing a DNS TXT record.
+	ACMEChallengeDNS01 ACMEChallengeType = "dns-01"
+)
+
+// ACMEResponder publishes the proof required to complete an ACME HTTP-01 or
+// DNS-01 challenge. kubeadm does not run an HTTP listener or own a DNS zone
+// itself, so actually serving the token/record is delegated to whatever the
+// operator plugs in here (e.g. a static pod sidecar for HTTP-01, or a DNS
+// provider webhook for DNS-01).
+type ACMEResponder interface {
+	// PresentHTTP01 makes keyAuth available at
+	// http://<domain>/.well-known/acme-challenge/<token> and returns once it
+	// is safe to assume the ACME server can reach it.
+	PresentHTTP01(ctx context.Context, token, keyAuth string) error
+	// PresentDNS01 publishes keyAuth as a TXT record at fqdn
+	// (_acme-challenge.<domain>) and returns once it is safe to assume the
+	// record has propagated.
+	PresentDNS01(ctx context.Context, fqdn, keyAuth string) error
+	// CleanUp removes whatever the matching Present* call published for
+	// token, once the authorization has been accepted (or failed).
+	CleanUp(ctx context.Context, token string) error
+}
+
+// RenewalPolicy configures, per managed certificate, which Renewer backend
+// kubeadm uses instead of the default local CA. It is not a field of
+// ClusterConfiguration and is not stored in the kubeadm-config ConfigMap: it
+// is installed on a Manager with SetRenewalPolicy, and `kubeadm certs renew`
+// builds it from the file given with --renewal-config.
+type RenewalPolicy struct {
+	// ACME lists the ACME configuration to use for the named certificates.
+	ACME map[string]ACMERenewalConfig
+
+	// ACMEResponder publishes the proof kubeadm's ACME client needs to
+	// complete the challenges configured in ACME. Unlike ACME, it is not
+	// read from the --renewal-config file; callers set it on the in-memory
+	// RenewalPolicy before invoking RenewWithPolicy.
+	ACMEResponder ACMEResponder
+}
+
+// ACMERenewalConfig holds the settings needed to obtain a certificate for a
+// single name (e.g. "apiserver") from an ACME (RFC 8555) CA.
+type ACMERenewalConfig struct {
+	// DirectoryURL is the ACME server's directory endpoint.
+	DirectoryURL string
+	// AccountKeyPath is a PEM-encoded EC private key used as the ACME account key.
+	AccountKeyPath string
+	// ChallengeType selects how kubeadm proves ownership of the cert's identifiers.
+	ChallengeType ACMEChallengeType
+	// Contact is an optional contact email registered with the ACME account.
+	Contact string
+	// EABKeyID and EABKey carry External Account Binding credentials, required
+	// by some private ACME CAs. EABKey is the base64url-encoded (no padding)
+	// HMAC key handed out by the CA alongside EABKeyID.
+	EABKeyID string
+	EABKey   string
+}
+
+// BackendFor returns the Renewer configured for a certificate name, if any.
+func (p *RenewalPolicy) BackendFor(name string) (Renewer, bool) {
+	if p == nil {
+		return nil, false
+	}
+	if cfg, ok := p.ACME[name]; ok {
+		return &acmeRenewer{cfg: cfg, responder: p.ACMEResponder}, true
+	}
+	return nil, false
+}
+
+// acmeRenewer obtains a renewed certificate from an ACME server, preserving
+// the SANs/CN/organization of the certificate being replaced.
+type acmeRenewer struct {
+	cfg       ACMERenewalConfig
+	responder ACMEResponder
+}
+
+func (r *acmeRenewer) Renew(handler *CertificateRenewHandler) (*x509.Certificate, crypto.Signer, error) {
+	if r.responder == nil {
+		return nil, nil, errors.Errorf("%s is configured for ACME renewal but no ACMEResponder is set; see RenewalPolicy.ACMEResponder", handler.Name)
+	}
+
+	cert, err := handler.readwriter.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg := certToConfig(cert)
+	applyOrganizationQuirks(handler, cfg)
+
+	accountKey, err := loadACMEAccountKey(r.cfg.AccountKeyPath)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "couldn't load ACME account key for %s", handler.Name)
+	}
+
+	client := &acme.Client{
+		DirectoryURL: r.cfg.DirectoryURL,
+		Key:          accountKey,
+	}
+
+	ctx := context.Background()
+
+	var contacts []string
+	if r.cfg.Contact != "" {
+		contacts = []string{"mailto:" + r.cfg.Contact}
+	}
+	account := &acme.Account{Contact: contacts}
+	if r.cfg.EABKeyID != "" {
+		eabKey, err := base64.RawURLEncoding.DecodeString(r.cfg.EABKey)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "couldn't decode the EAB key configured for %s", handler.Name)
+		}
+		account.ExternalAccountBinding = &acme.ExternalAccountBinding{
+			KID: r.cfg.EABKeyID,
+			Key: eabKey,
+		}
+	}
+	// Per RFC 8555 section 7.3.1, a new-account request for a key that
+	// already has an account returns that existing account instead of
+	// erroring; acme.Client reports that case as ErrAccountAlreadyExists
+	// (after caching the account URL), which is treated as success here, so
+	// Register is safe to call unconditionally on every renewal without
+	// first checking for an existing registration.
+	if _, err := client.Register(ctx, account, acme.AcceptTOS); err != nil && err != acme.ErrAccountAlreadyExists {
+		return nil, nil, errors.Wrapf(err, "failed to register ACME account for %s", handler.Name)
+	}
+
+	ids := make([]acme.AuthzID, 0, len(cfg.AltNames.DNSNames))
+	for _, dnsName := range cfg.AltNames.DNSNames {
+		ids = append(ids, acme.AuthzID{Type: "dns", Value: dnsName})
+	}
+
+	order, err := client.AuthorizeOrder(ctx, ids)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to create ACME order for %s", handler.Name)
+	}
+
+	for _, authzURL := range order.AuthzURLs {
+		if err := r.solveAuthorization(ctx, client, authzURL); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to solve ACME challenge for %s", handler.Name)
+		}
+	}
+
+	order, err = client.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "ACME order for %s did not become ready", handler.Name)
+	}
+
+	certKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	csrDER, err := newCSRForKey(cfg, certKey)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to build ACME CSR for %s", handler.Name)
+	}
+
+	derCerts, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csrDER, true)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to finalize ACME order for %s", handler.Name)
+	}
+
+	issued, err := x509.ParseCertificate(derCerts[0])
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to parse the certificate issued for %s", handler.Name)
+	}
+
+	return issued, certKey, nil
+}
+
+// solveAuthorization walks a single ACME authorization to completion using
+// the configured challenge type.
+func (r *acmeRenewer) solveAuthorization(ctx context.Context, client *acme.Client, authzURL string) error {
+	authz, err := client.GetAuthorization(ctx, authzURL)
+	if err != nil {
+		return err
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var chal *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == string(r.cfg.ChallengeType) {
+			chal = c
+			break
+		}
+	}
+	if chal == nil {
+		return errors.Errorf("no %s challenge offered for %s", r.cfg.ChallengeType, authz.Identifier.Value)
+	}
+
+	if err := presentChallenge(ctx, client, r.responder, authz, chal); err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.responder.CleanUp(ctx, chal.Token)
+	}()
+
+	if _, err := client.Accept(ctx, chal); err != nil {
+		return err
+	}
+
+	_, err = client.WaitAuthorization(ctx, authzURL)
+	return err
+}
+
+// presentChallenge computes the key authorization for chal and publishes it
+// through responder, so the ACME server can later reach it when client.Accept
+// asks it to validate the challenge.
+func presentChallenge(ctx context.Context, client *acme.Client, responder ACMEResponder, authz *acme.Authorization, chal *acme.Challenge) error {
+	switch chal.Type {
+	case string(ACMEChallengeHTTP01):
+		keyAuth, err := client.HTTP01ChallengeResponse(chal.Token)
+		if err != nil {
+			return err
+		}
+		return responder.PresentHTTP01(ctx, chal.Token, keyAuth)
+	case string(ACMEChallengeDNS01):
+		keyAuth, err := client.DNS01ChallengeRecord(chal.Token)
+		if err != nil {
+			return err
+		}
+		return responder.PresentDNS01(ctx, "_acme-challenge."+authz.Identifier.Value, keyAuth)
+	default:
+		return errors.Errorf("unsupported ACME challenge type %q", chal.Type)
+	}
+}
+
+// newCSRForKey builds a DER-encoded CertificateRequest for cfg, signed by
+// key. The ACME finalize call must submit a CSR bound to the certKey already
+// generated for this renewal, rather than one generated alongside a fresh
+// key, so the request is built directly instead of going through pkiutil.
+func newCSRForKey(cfg *certutil.Config, key crypto.Signer) ([]byte, error) {
+	template := &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   cfg.CommonName,
+			Organization: cfg.Organization,
+		},
+		DNSNames:    cfg.AltNames.DNSNames,
+		IPAddresses: cfg.AltNames.IPs,
+	}
+	return x509.CreateCertificateRequest(rand.Reader, template, key)
+}
+
+func loadACMEAccountKey(path string) (crypto.Signer, error) {
+	keyPEM, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := clientkeyutil.ParsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("the ACME account key at %s does not implement crypto.Signer", path)
+	}
+	return signer, nil
+}
@@ -0,0 +1,484 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	certtestutil "k8s.io/kubernetes/cmd/kubeadm/app/util/certs"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+	testutil "k8s.io/kubernetes/cmd/kubeadm/test"
+)
+
+func TestRenewalPolicyBackendFor(t *testing.T) {
+	policy := &RenewalPolicy{
+		ACME: map[string]ACMERenewalConfig{
+			"apiserver": {
+				DirectoryURL:  "https://acme.example.com/directory",
+				ChallengeType: ACMEChallengeHTTP01,
+			},
+		},
+	}
+
+	t.Run("certificate configured for ACME", func(t *testing.T) {
+		backend, ok := policy.BackendFor("apiserver")
+		if !ok {
+			t.Fatal("expected a backend for apiserver")
+		}
+		if _, isACME := backend.(*acmeRenewer); !isACME {
+			t.Fatalf("expected an acmeRenewer, got %T", backend)
+		}
+	})
+
+	t.Run("certificate not configured falls back to local CA", func(t *testing.T) {
+		if _, ok := policy.BackendFor("controller-manager.conf"); ok {
+			t.Fatal("expected no backend for controller-manager.conf")
+		}
+	})
+
+	t.Run("nil policy never selects a backend", func(t *testing.T) {
+		var nilPolicy *RenewalPolicy
+		if _, ok := nilPolicy.BackendFor("apiserver"); ok {
+			t.Fatal("expected no backend from a nil policy")
+		}
+	})
+}
+
+// fakeACMEResponder records whatever acmeRenewer publishes for a challenge,
+// standing in for the static pod sidecar/DNS webhook a real cluster would
+// plug in as RenewalPolicy.ACMEResponder.
+type fakeACMEResponder struct {
+	mu        sync.Mutex
+	published map[string]string // token/fqdn -> key authorization
+}
+
+func newFakeACMEResponder() *fakeACMEResponder {
+	return &fakeACMEResponder{published: map[string]string{}}
+}
+
+func (f *fakeACMEResponder) PresentHTTP01(ctx context.Context, token, keyAuth string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.published[token] = keyAuth
+	return nil
+}
+
+func (f *fakeACMEResponder) PresentDNS01(ctx context.Context, fqdn, keyAuth string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.published[fqdn] = keyAuth
+	return nil
+}
+
+func (f *fakeACMEResponder) CleanUp(ctx context.Context, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.published, token)
+	return nil
+}
+
+func (f *fakeACMEResponder) has(key string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.published[key]
+	return ok
+}
+
+// fakeACMEDirectory is a minimal RFC 8555 server, analogous in spirit to
+// fakecertificateReadWriter: just enough of the wire protocol to drive a
+// single acmeRenewer.Renew call through order -> challenge -> finalize,
+// issuing a certificate signed by caCert/caKey whose Subject/SANs come from
+// the CSR the client submits at finalize time.
+type fakeACMEDirectory struct {
+	srv       *httptest.Server
+	caCert    *x509.Certificate
+	caKey     crypto.Signer
+	responder *fakeACMEResponder
+	challenge ACMEChallengeType
+	token     string
+
+	mu         sync.Mutex
+	authzValid bool
+	issued     bool
+	issuedDER  []byte
+	sawEAB     bool
+}
+
+func (d *fakeACMEDirectory) sawExternalAccountBinding() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.sawEAB
+}
+
+func newFakeACMEDirectory(t *testing.T, caCert *x509.Certificate, caKey crypto.Signer, responder *fakeACMEResponder, challenge ACMEChallengeType) *fakeACMEDirectory {
+	t.Helper()
+	d := &fakeACMEDirectory{caCert: caCert, caKey: caKey, responder: responder, challenge: challenge, token: "test-token"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/directory", d.handleDirectory)
+	mux.HandleFunc("/new-nonce", d.handleNonce)
+	mux.HandleFunc("/new-account", d.handleNewAccount)
+	mux.HandleFunc("/new-order", d.handleNewOrder)
+	mux.HandleFunc("/authz/1", d.handleAuthz)
+	mux.HandleFunc("/chal/1", d.handleChallenge)
+	mux.HandleFunc("/order/1", d.handleOrder)
+	mux.HandleFunc("/finalize/1", d.handleFinalize(t))
+	mux.HandleFunc("/cert/1", d.handleCert)
+
+	d.srv = httptest.NewServer(mux)
+	return d
+}
+
+func (d *fakeACMEDirectory) Close() { d.srv.Close() }
+
+func (d *fakeACMEDirectory) challengeURL() string { return d.srv.URL + "/chal/1" }
+
+func (d *fakeACMEDirectory) setNonce(w http.ResponseWriter) {
+	w.Header().Set("Replay-Nonce", "test-nonce")
+}
+
+func (d *fakeACMEDirectory) handleDirectory(w http.ResponseWriter, r *http.Request) {
+	d.setNonce(w)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"newNonce":   d.srv.URL + "/new-nonce",
+		"newAccount": d.srv.URL + "/new-account",
+		"newOrder":   d.srv.URL + "/new-order",
+	})
+}
+
+func (d *fakeACMEDirectory) handleNonce(w http.ResponseWriter, r *http.Request) {
+	d.setNonce(w)
+}
+
+func (d *fakeACMEDirectory) handleNewAccount(w http.ResponseWriter, r *http.Request) {
+	d.setNonce(w)
+
+	if payload, err := decodeJWSPayload(r); err == nil {
+		if _, ok := payload["externalAccountBinding"]; ok {
+			d.mu.Lock()
+			d.sawEAB = true
+			d.mu.Unlock()
+		}
+	}
+
+	w.Header().Set("Location", d.srv.URL+"/account/1")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]string{"status": "valid"})
+}
+
+func (d *fakeACMEDirectory) handleNewOrder(w http.ResponseWriter, r *http.Request) {
+	d.setNonce(w)
+	w.Header().Set("Location", d.srv.URL+"/order/1")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":         "pending",
+		"authorizations": []string{d.srv.URL + "/authz/1"},
+		"finalize":       d.srv.URL + "/finalize/1",
+	})
+}
+
+func (d *fakeACMEDirectory) handleAuthz(w http.ResponseWriter, r *http.Request) {
+	d.setNonce(w)
+	d.mu.Lock()
+	valid := d.authzValid
+	d.mu.Unlock()
+
+	status := "pending"
+	if valid {
+		status = "valid"
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":     status,
+		"identifier": map[string]string{"type": "dns", "value": "test-domain.space"},
+		"challenges": []map[string]string{{
+			"type":   string(d.challenge),
+			"url":    d.challengeURL(),
+			"token":  d.token,
+			"status": status,
+		}},
+	})
+}
+
+// handleChallenge stands in for the ACME server validating the challenge: it
+// asserts that acmeRenewer actually published a key authorization before
+// asking for validation, which is the bug this test guards against.
+func (d *fakeACMEDirectory) handleChallenge(w http.ResponseWriter, r *http.Request) {
+	d.setNonce(w)
+
+	published := d.responder.has(d.token) || d.responder.has("_acme-challenge.test-domain.space")
+	if !published {
+		http.Error(w, "challenge response was never published", http.StatusBadRequest)
+		return
+	}
+
+	d.mu.Lock()
+	d.authzValid = true
+	d.mu.Unlock()
+
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"type":   string(d.challenge),
+		"url":    d.challengeURL(),
+		"token":  d.token,
+		"status": "valid",
+	})
+}
+
+func (d *fakeACMEDirectory) handleOrder(w http.ResponseWriter, r *http.Request) {
+	d.setNonce(w)
+	d.mu.Lock()
+	valid := d.authzValid
+	issued := d.issued
+	d.mu.Unlock()
+
+	status := "pending"
+	switch {
+	case issued:
+		status = "valid"
+	case valid:
+		status = "ready"
+	}
+
+	resp := map[string]interface{}{
+		"status":         status,
+		"authorizations": []string{d.srv.URL + "/authz/1"},
+		"finalize":       d.srv.URL + "/finalize/1",
+	}
+	if issued {
+		resp["certificate"] = d.srv.URL + "/cert/1"
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (d *fakeACMEDirectory) handleFinalize(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		d.setNonce(w)
+
+		csr, err := decodeCSRFromJWS(r)
+		if err != nil {
+			t.Errorf("failed to decode CSR from finalize request: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+		if err != nil {
+			t.Fatalf("failed to generate a serial number: %v", err)
+		}
+
+		template := &x509.Certificate{
+			SerialNumber: serial,
+			Subject:      csr.Subject,
+			DNSNames:     csr.DNSNames,
+			IPAddresses:  csr.IPAddresses,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, template, d.caCert, csr.PublicKey, d.caKey)
+		if err != nil {
+			t.Errorf("failed to sign the fake-issued certificate: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		d.mu.Lock()
+		d.issuedDER = der
+		d.issued = true
+		d.mu.Unlock()
+
+		w.Header().Set("Location", d.srv.URL+"/order/1")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":      "valid",
+			"finalize":    d.srv.URL + "/finalize/1",
+			"certificate": d.srv.URL + "/cert/1",
+		})
+	}
+}
+
+func (d *fakeACMEDirectory) handleCert(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	der := d.issuedDER
+	d.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/pem-certificate-chain")
+	_ = pem.Encode(w, &pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// decodeJWSPayload pulls the base64url-encoded JSON payload out of the
+// flattened JWS body acme.Client POSTs to the ACME server.
+func decodeJWSPayload(r *http.Request) (map[string]interface{}, error) {
+	var jws struct {
+		Payload string `json:"payload"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&jws); err != nil {
+		return nil, err
+	}
+	payloadJSON, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+	if err != nil {
+		return nil, err
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(payloadJSON, &payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// decodeCSRFromJWS pulls the base64url-encoded "csr" field out of the
+// flattened JWS body acme.Client POSTs to the finalize endpoint.
+func decodeCSRFromJWS(r *http.Request) (*x509.CertificateRequest, error) {
+	payload, err := decodeJWSPayload(r)
+	if err != nil {
+		return nil, err
+	}
+	csr, _ := payload["csr"].(string)
+	der, err := base64.RawURLEncoding.DecodeString(csr)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificateRequest(der)
+}
+
+func TestACMERenewerRenew(t *testing.T) {
+	dir := testutil.SetupTempDir(t)
+	defer os.RemoveAll(dir)
+
+	existing := writeTestCertificate(t, dir, "apiserver", testCACert, testCAKey, testCertOrganization)
+
+	accountKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate a fake ACME account key: %v", err)
+	}
+	if err := pkiutil.WriteKey(dir, "acme-account", accountKey); err != nil {
+		t.Fatalf("failed to write the fake ACME account key: %v", err)
+	}
+
+	responder := newFakeACMEResponder()
+	fake := newFakeACMEDirectory(t, testCACert, testCAKey, responder, ACMEChallengeHTTP01)
+	defer fake.Close()
+
+	r := &acmeRenewer{
+		cfg: ACMERenewalConfig{
+			DirectoryURL:   fake.srv.URL + "/directory",
+			AccountKeyPath: filepath.Join(dir, "acme-account.key"),
+			ChallengeType:  ACMEChallengeHTTP01,
+		},
+		responder: responder,
+	}
+
+	handler := &CertificateRenewHandler{
+		Name:       "apiserver",
+		readwriter: &fakecertificateReadWriter{exist: true, cert: existing},
+	}
+
+	issued, key, err := r.Renew(handler)
+	if err != nil {
+		t.Fatalf("unexpected error renewing through the fake ACME directory: %v", err)
+	}
+	if key == nil {
+		t.Fatal("expected a freshly generated private key")
+	}
+
+	certtestutil.AssertCertificateIsSignedByCa(t, issued, testCACert)
+
+	if issued.Subject.CommonName != existing.Subject.CommonName {
+		t.Errorf("expected CommonName %q, got %q", existing.Subject.CommonName, issued.Subject.CommonName)
+	}
+	if !reflect.DeepEqual(issued.Subject.Organization, existing.Subject.Organization) {
+		t.Errorf("expected Organization %v, got %v", existing.Subject.Organization, issued.Subject.Organization)
+	}
+
+	wantDNSNames := append([]string{}, existing.DNSNames...)
+	gotDNSNames := append([]string{}, issued.DNSNames...)
+	sort.Strings(wantDNSNames)
+	sort.Strings(gotDNSNames)
+	if !reflect.DeepEqual(wantDNSNames, gotDNSNames) {
+		t.Errorf("expected DNSNames %v, got %v", wantDNSNames, gotDNSNames)
+	}
+
+	if responder.has(fake.token) {
+		t.Error("expected the challenge response to be cleaned up once the authorization completed")
+	}
+}
+
+func TestACMERenewerRenewWithEAB(t *testing.T) {
+	dir := testutil.SetupTempDir(t)
+	defer os.RemoveAll(dir)
+
+	existing := writeTestCertificate(t, dir, "apiserver", testCACert, testCAKey, testCertOrganization)
+
+	accountKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate a fake ACME account key: %v", err)
+	}
+	if err := pkiutil.WriteKey(dir, "acme-account", accountKey); err != nil {
+		t.Fatalf("failed to write the fake ACME account key: %v", err)
+	}
+
+	responder := newFakeACMEResponder()
+	fake := newFakeACMEDirectory(t, testCACert, testCAKey, responder, ACMEChallengeHTTP01)
+	defer fake.Close()
+
+	r := &acmeRenewer{
+		cfg: ACMERenewalConfig{
+			DirectoryURL:   fake.srv.URL + "/directory",
+			AccountKeyPath: filepath.Join(dir, "acme-account.key"),
+			ChallengeType:  ACMEChallengeHTTP01,
+			EABKeyID:       "test-eab-kid",
+			EABKey:         base64.RawURLEncoding.EncodeToString([]byte("test-eab-key-material")),
+		},
+		responder: responder,
+	}
+
+	handler := &CertificateRenewHandler{
+		Name:       "apiserver",
+		readwriter: &fakecertificateReadWriter{exist: true, cert: existing},
+	}
+
+	if _, _, err := r.Renew(handler); err != nil {
+		t.Fatalf("unexpected error renewing through the fake ACME directory: %v", err)
+	}
+
+	if !fake.sawExternalAccountBinding() {
+		t.Error("expected the new-account request to carry an externalAccountBinding")
+	}
+}
+
+func TestACMERenewerRenewWithoutResponder(t *testing.T) {
+	r := &acmeRenewer{cfg: ACMERenewalConfig{DirectoryURL: "https://acme.example.com/directory"}}
+	handler := &CertificateRenewHandler{Name: "apiserver", readwriter: &fakecertificateReadWriter{exist: true, cert: testCACert}}
+
+	if _, _, err := r.Renew(handler); err == nil {
+		t.Fatal("expected an error when no ACMEResponder is configured")
+	}
+}
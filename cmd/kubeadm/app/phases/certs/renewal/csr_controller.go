@@ -0,0 +1,278 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	certutil "k8s.io/client-go/util/cert"
+
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+)
+
+// maxCSRRetries bounds how many CertificateSigningRequests, the first one
+// included, RenewViaAPI submits for a certificate while the cluster's signer
+// keeps denying or failing them, so a single misbehaving signer can't retry
+// forever.
+const maxCSRRetries = 3
+
+// CSRController submits CertificateSigningRequests for managed certificates
+// to the cluster's certificates.k8s.io/v1 API instead of writing .csr/.key
+// files to disk for out-of-band signing, waits for them to be approved and
+// issued, and persists the issued certificate through the same
+// certificateReadWriter used by RenewUsingLocalCA.
+type CSRController struct {
+	client     kubernetes.Interface
+	signerName string
+}
+
+// NewCSRController returns a CSRController that submits CSRs against client
+// using signerName (e.g. "kubernetes.io/kube-apiserver-client-kubelet", or a
+// custom signer configured on the cluster).
+func NewCSRController(client kubernetes.Interface, signerName string) *CSRController {
+	return &CSRController{client: client, signerName: signerName}
+}
+
+// RenewViaAPI submits a CSR for the named certificate, waits for it to be
+// approved and issued, persists the resulting certificate through the
+// Manager's certificateReadWriter, and removes the CSR object once done.
+func (rm *Manager) RenewViaAPI(ctx context.Context, name string, controller *CSRController) (*x509.Certificate, error) {
+	handler, ok := rm.certificates[name]
+	if !ok {
+		return nil, errors.Errorf("%s is not a known certificate", name)
+	}
+
+	if rm.keyStorage.isKMSBacked(name) {
+		return nil, errors.Errorf("%s's private key is held by a remote signer; renewing it via a CertificateSigningRequest would generate a new local key and is not supported", name)
+	}
+
+	cert, err := handler.readwriter.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := certToConfig(cert)
+	applyOrganizationQuirks(handler, cfg)
+
+	csrDER, key, err := pkiutil.NewCSRAndKey(&pkiutil.CertConfig{Config: *cfg})
+	if err != nil {
+		return nil, err
+	}
+
+	issued, err := controller.submitAndWait(ctx, name, csrDER, cfg.Usages)
+	if err != nil {
+		recordRenewal(name, err)
+		return nil, err
+	}
+
+	err = handler.readwriter.Write(issued, key)
+	recordRenewal(name, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return issued, nil
+}
+
+// RenewAllViaAPI renews every certificate known to the Manager through the
+// online CSR-signing flow, with at most maxConcurrent renewals in flight at
+// once. It returns the names of the certificates it successfully renewed and
+// the first error encountered, if any; it does not stop early on error so a
+// single stuck CSR does not block the rest of the fleet.
+func (rm *Manager) RenewAllViaAPI(ctx context.Context, controller *CSRController, maxConcurrent int) ([]string, error) {
+	handlers := rm.Certificates()
+
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+
+	type result struct {
+		name string
+		err  error
+	}
+
+	sem := make(chan struct{}, maxConcurrent)
+	results := make(chan result, len(handlers))
+
+	for _, handler := range handlers {
+		handler := handler
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem }()
+			_, err := rm.RenewViaAPI(ctx, handler.Name, controller)
+			results <- result{name: handler.Name, err: err}
+		}()
+	}
+
+	var renewed []string
+	var firstErr error
+	for range handlers {
+		r := <-results
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = errors.Wrapf(r.err, "failed to renew %s", r.name)
+			}
+			continue
+		}
+		renewed = append(renewed, r.name)
+	}
+
+	return renewed, firstErr
+}
+
+// submitAndWait creates a CSR object for csrDER, watches it through
+// Pending -> Approved -> Issued, and returns the issued certificate. A
+// Denied or Failed condition causes it to submit a fresh CSR and try again,
+// making at most maxCSRRetries attempts in all, since either can reflect a transient decision by
+// an external approver rather than the CSR itself being unfixably bad.
+func (c *CSRController) submitAndWait(ctx context.Context, name string, csrDER *x509.CertificateRequest, usages []x509.ExtKeyUsage) (*x509.Certificate, error) {
+	csrPEM := encodeCSRPEM(csrDER)
+
+	var lastErr error
+	for attempt := 1; attempt <= maxCSRRetries; attempt++ {
+		cert, retriable, err := c.submitOnce(ctx, name, csrPEM, usages)
+		if err == nil {
+			return cert, nil
+		}
+		if !retriable {
+			return nil, err
+		}
+		lastErr = err
+	}
+
+	return nil, errors.Wrapf(lastErr, "gave up on a CertificateSigningRequest for %s after %d attempts", name, maxCSRRetries)
+}
+
+// submitOnce creates a single CSR object and waits for it to be approved and
+// issued, deleting it once done regardless of outcome.
+func (c *CSRController) submitOnce(ctx context.Context, name string, csrPEM []byte, usages []x509.ExtKeyUsage) (*x509.Certificate, bool, error) {
+	csr := &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("kubeadm-renew-%s-", name),
+		},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:    csrPEM,
+			SignerName: c.signerName,
+			Usages:     toCSRUsages(usages),
+		},
+	}
+
+	created, err := c.client.CertificatesV1().CertificateSigningRequests().Create(ctx, csr, metav1.CreateOptions{})
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "failed to create a CertificateSigningRequest for %s", name)
+	}
+
+	defer func() {
+		_ = c.client.CertificatesV1().CertificateSigningRequests().Delete(context.Background(), created.Name, metav1.DeleteOptions{})
+	}()
+
+	return c.waitForIssuance(ctx, created.Name)
+}
+
+// waitForIssuance watches the named CSR until it is issued, denied, failed,
+// or ctx is cancelled. The returned bool reports whether the caller should
+// retry with a fresh CSR (true only for a Denied/Failed condition).
+func (c *CSRController) waitForIssuance(ctx context.Context, name string) (*x509.Certificate, bool, error) {
+	watcher, err := c.client.CertificatesV1().CertificateSigningRequests().Watch(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String(),
+	})
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "failed to watch CertificateSigningRequest %s", name)
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil, false, errors.Errorf("watch closed before CertificateSigningRequest %s was issued", name)
+			}
+			if event.Type == watch.Deleted {
+				return nil, false, errors.Errorf("CertificateSigningRequest %s was deleted before it was issued", name)
+			}
+
+			csr, ok := event.Object.(*certificatesv1.CertificateSigningRequest)
+			if !ok {
+				continue
+			}
+
+			if cert, done, retriable, err := csrOutcome(csr); done {
+				return cert, retriable, err
+			}
+		}
+	}
+}
+
+// csrOutcome inspects a CSR's conditions and returns (cert, true, false, nil)
+// once issued, (nil, true, true, err) once Denied/Failed, or
+// (nil, false, false, nil) while still pending approval/issuance.
+func csrOutcome(csr *certificatesv1.CertificateSigningRequest) (*x509.Certificate, bool, bool, error) {
+	for _, cond := range csr.Status.Conditions {
+		switch cond.Type {
+		case certificatesv1.CertificateDenied:
+			return nil, true, true, errors.Errorf("CertificateSigningRequest %s was denied: %s", csr.Name, cond.Message)
+		case certificatesv1.CertificateFailed:
+			return nil, true, true, errors.Errorf("CertificateSigningRequest %s failed: %s", csr.Name, cond.Message)
+		}
+	}
+
+	if len(csr.Status.Certificate) == 0 {
+		return nil, false, false, nil
+	}
+
+	certs, err := certutil.ParseCertsPEM(csr.Status.Certificate)
+	if err != nil {
+		return nil, true, false, errors.Wrapf(err, "failed to parse the certificate issued for %s", csr.Name)
+	}
+
+	return certs[0], true, false, nil
+}
+
+// encodeCSRPEM PEM-encodes a certificate request. client-go's util/cert
+// package only exposes EncodeCertPEM, so CSRs are encoded locally instead.
+func encodeCSRPEM(csr *x509.CertificateRequest) []byte {
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE REQUEST",
+		Bytes: csr.Raw,
+	})
+}
+
+func toCSRUsages(usages []x509.ExtKeyUsage) []certificatesv1.KeyUsage {
+	result := make([]certificatesv1.KeyUsage, 0, len(usages))
+	for _, usage := range usages {
+		switch usage {
+		case x509.ExtKeyUsageClientAuth:
+			result = append(result, certificatesv1.UsageClientAuth)
+		case x509.ExtKeyUsageServerAuth:
+			result = append(result, certificatesv1.UsageServerAuth)
+		}
+	}
+	return result
+}
@@ -0,0 +1,323 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	clientcertutil "k8s.io/client-go/util/cert"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	testutil "k8s.io/kubernetes/cmd/kubeadm/test"
+)
+
+// newWatchingClient returns a fake clientset whose CSR watch is backed by a
+// watch.FakeWatcher the test can push events into.
+func newWatchingClient(t *testing.T) (*fake.Clientset, *watch.FakeWatcher) {
+	t.Helper()
+	client := fake.NewSimpleClientset()
+	watcher := watch.NewFake()
+	client.PrependWatchReactor("certificatesigningrequests", clienttesting.DefaultWatchReactor(watcher, nil))
+	return client, watcher
+}
+
+// newSequentialWatchingClient returns a fake clientset that hands out a fresh
+// watch.FakeWatcher on every Watch call, mirroring how a real apiserver opens
+// an independent stream each time submitOnce resubmits a fresh CSR. The
+// returned func blocks until the next Watch call has happened and returns its
+// watcher.
+func newSequentialWatchingClient(t *testing.T) (*fake.Clientset, func() *watch.FakeWatcher) {
+	t.Helper()
+	client := fake.NewSimpleClientset()
+	watchers := make(chan *watch.FakeWatcher, maxCSRRetries)
+	client.PrependWatchReactor("certificatesigningrequests", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		w := watch.NewFake()
+		watchers <- w
+		return true, w, nil
+	})
+	return client, func() *watch.FakeWatcher { return <-watchers }
+}
+
+// countCreates returns how many CertificateSigningRequest objects were
+// created against client.
+func countCreates(client *fake.Clientset) int {
+	count := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "create" && action.GetResource().Resource == "certificatesigningrequests" {
+			count++
+		}
+	}
+	return count
+}
+
+func TestCSRControllerWaitForIssuance(t *testing.T) {
+	dir := testutil.SetupTempDir(t)
+	defer os.RemoveAll(dir)
+
+	issuedCert := writeTestCertificate(t, dir, "apiserver", testCACert, testCAKey, testCertOrganization)
+
+	tests := []struct {
+		name      string
+		drive     func(watcher *watch.FakeWatcher)
+		expectErr bool
+	}{
+		{
+			name: "Pending then Approved then Issued",
+			drive: func(watcher *watch.FakeWatcher) {
+				csr := &certificatesv1.CertificateSigningRequest{}
+				csr.Name = "test-csr"
+				watcher.Modify(csr.DeepCopy())
+
+				csr.Status.Conditions = []certificatesv1.CertificateSigningRequestCondition{
+					{Type: certificatesv1.CertificateApproved, Status: "True"},
+				}
+				watcher.Modify(csr.DeepCopy())
+
+				csr.Status.Certificate = clientcertutil.EncodeCertPEM(issuedCert)
+				watcher.Modify(csr.DeepCopy())
+			},
+		},
+		{
+			name: "Denied",
+			drive: func(watcher *watch.FakeWatcher) {
+				csr := &certificatesv1.CertificateSigningRequest{}
+				csr.Name = "test-csr"
+				csr.Status.Conditions = []certificatesv1.CertificateSigningRequestCondition{
+					{Type: certificatesv1.CertificateDenied, Status: "True", Message: "not allowed"},
+				}
+				watcher.Modify(csr.DeepCopy())
+			},
+			expectErr: true,
+		},
+		{
+			name: "Failed",
+			drive: func(watcher *watch.FakeWatcher) {
+				csr := &certificatesv1.CertificateSigningRequest{}
+				csr.Name = "test-csr"
+				csr.Status.Conditions = []certificatesv1.CertificateSigningRequestCondition{
+					{Type: certificatesv1.CertificateFailed, Status: "True", Message: "signer unavailable"},
+				}
+				watcher.Modify(csr.DeepCopy())
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, watcher := newWatchingClient(t)
+			controller := NewCSRController(client, "kubernetes.io/kube-apiserver-client")
+
+			go test.drive(watcher)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			cert, retriable, err := controller.waitForIssuance(ctx, "test-csr")
+			if test.expectErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				if !retriable {
+					t.Fatal("expected a Denied/Failed CSR to be reported as retriable")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cert.SerialNumber.Cmp(issuedCert.SerialNumber) != 0 {
+				t.Fatal("expected the parsed certificate to match the one encoded in Status.Certificate")
+			}
+		})
+	}
+}
+
+// TestSubmitAndWaitRetriesDeniedCSR drives submitAndWait through a Denied
+// first attempt followed by a successful second attempt, verifying it
+// resubmits a fresh CertificateSigningRequest (rather than giving up or
+// reusing the denied one) and returns the certificate issued for the retry.
+func TestSubmitAndWaitRetriesDeniedCSR(t *testing.T) {
+	dir := testutil.SetupTempDir(t)
+	defer os.RemoveAll(dir)
+
+	issuedCert := writeTestCertificate(t, dir, "apiserver", testCACert, testCAKey, testCertOrganization)
+
+	client, nextWatcher := newSequentialWatchingClient(t)
+	controller := NewCSRController(client, "kubernetes.io/kube-apiserver-client")
+
+	go func() {
+		denied := &certificatesv1.CertificateSigningRequest{}
+		denied.Name = "attempt-1"
+		denied.Status.Conditions = []certificatesv1.CertificateSigningRequestCondition{
+			{Type: certificatesv1.CertificateDenied, Status: "True", Message: "try again"},
+		}
+		nextWatcher().Modify(denied.DeepCopy())
+
+		issued := &certificatesv1.CertificateSigningRequest{}
+		issued.Name = "attempt-2"
+		issued.Status.Certificate = clientcertutil.EncodeCertPEM(issuedCert)
+		nextWatcher().Modify(issued.DeepCopy())
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cert, err := controller.submitAndWait(ctx, "apiserver", &x509.CertificateRequest{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cert.SerialNumber.Cmp(issuedCert.SerialNumber) != 0 {
+		t.Fatal("expected the certificate issued for the retried CSR")
+	}
+
+	if got := countCreates(client); got != 2 {
+		t.Fatalf("expected submitAndWait to create 2 CertificateSigningRequests (1 denied + 1 retry), got %d", got)
+	}
+}
+
+// TestSubmitAndWaitGivesUpAfterMaxRetries verifies submitAndWait stops
+// resubmitting once maxCSRRetries denied/failed attempts have been made,
+// rather than retrying forever.
+func TestSubmitAndWaitGivesUpAfterMaxRetries(t *testing.T) {
+	client, nextWatcher := newSequentialWatchingClient(t)
+	controller := NewCSRController(client, "kubernetes.io/kube-apiserver-client")
+
+	go func() {
+		for i := 0; i < maxCSRRetries; i++ {
+			denied := &certificatesv1.CertificateSigningRequest{}
+			denied.Name = fmt.Sprintf("attempt-%d", i+1)
+			denied.Status.Conditions = []certificatesv1.CertificateSigningRequestCondition{
+				{Type: certificatesv1.CertificateDenied, Status: "True", Message: "nope"},
+			}
+			nextWatcher().Modify(denied.DeepCopy())
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := controller.submitAndWait(ctx, "apiserver", &x509.CertificateRequest{}, nil); err == nil {
+		t.Fatal("expected submitAndWait to give up after maxCSRRetries denied attempts")
+	}
+
+	if got := countCreates(client); got != maxCSRRetries {
+		t.Fatalf("expected exactly %d CertificateSigningRequests to be created, got %d", maxCSRRetries, got)
+	}
+}
+
+func TestCSROutcome(t *testing.T) {
+	dir := testutil.SetupTempDir(t)
+	defer os.RemoveAll(dir)
+	cert := writeTestCertificate(t, dir, "apiserver", testCACert, testCAKey, testCertOrganization)
+
+	pending := &certificatesv1.CertificateSigningRequest{}
+	if _, done, retriable, err := csrOutcome(pending); done || retriable || err != nil {
+		t.Fatalf("expected a pending CSR to report not-done, got done=%v retriable=%v err=%v", done, retriable, err)
+	}
+
+	issued := &certificatesv1.CertificateSigningRequest{
+		Status: certificatesv1.CertificateSigningRequestStatus{
+			Certificate: clientcertutil.EncodeCertPEM(cert),
+		},
+	}
+	got, done, retriable, err := csrOutcome(issued)
+	if !done || retriable || err != nil {
+		t.Fatalf("expected an issued CSR to report done with no error, got done=%v retriable=%v err=%v", done, retriable, err)
+	}
+	if got.SerialNumber.Cmp(cert.SerialNumber) != 0 {
+		t.Fatal("expected the parsed certificate to match the one encoded in Status.Certificate")
+	}
+
+	denied := &certificatesv1.CertificateSigningRequest{
+		Status: certificatesv1.CertificateSigningRequestStatus{
+			Conditions: []certificatesv1.CertificateSigningRequestCondition{
+				{Type: certificatesv1.CertificateDenied},
+			},
+		},
+	}
+	if _, done, retriable, err := csrOutcome(denied); !done || !retriable || err == nil {
+		t.Fatal("expected a denied CSR to report done, retriable, and with an error")
+	}
+}
+
+func TestRenewViaAPIRefusesKMSBacked(t *testing.T) {
+	dir := testutil.SetupTempDir(t)
+	defer os.RemoveAll(dir)
+
+	writeTestCertificate(t, dir, "apiserver", testCACert, testCAKey, testCertOrganization)
+
+	cfg := &kubeadmapi.ClusterConfiguration{
+		Etcd:            kubeadmapi.Etcd{External: &kubeadmapi.ExternalEtcd{}},
+		CertificatesDir: dir,
+	}
+	rm, err := NewManager(cfg, dir)
+	if err != nil {
+		t.Fatalf("Failed to create the certificate renewal manager: %v", err)
+	}
+
+	rm.SetKeyStorage(&KeyStorage{KMSBacked: map[string]bool{"apiserver": true}})
+
+	client, _ := newWatchingClient(t)
+	controller := NewCSRController(client, "kubernetes.io/kube-apiserver-client")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := rm.RenewViaAPI(ctx, "apiserver", controller); err == nil {
+		t.Fatal("expected RenewViaAPI to refuse a KMS-backed certificate, got no error")
+	}
+}
+
+func TestRenewAllViaAPIClampsMaxConcurrent(t *testing.T) {
+	dir := testutil.SetupTempDir(t)
+	defer os.RemoveAll(dir)
+
+	cfg := &kubeadmapi.ClusterConfiguration{
+		Etcd:            kubeadmapi.Etcd{External: &kubeadmapi.ExternalEtcd{}},
+		CertificatesDir: dir,
+	}
+	rm, err := NewManager(cfg, dir)
+	if err != nil {
+		t.Fatalf("Failed to create the certificate renewal manager: %v", err)
+	}
+
+	client, _ := newWatchingClient(t)
+	controller := NewCSRController(client, "kubernetes.io/kube-apiserver-client")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// maxConcurrent <= 0 must not deadlock; every certificate fails to read
+	// (none were written to dir), but RenewAllViaAPI must still return.
+	if _, err := rm.RenewAllViaAPI(ctx, controller, 0); err == nil {
+		t.Fatal("expected an error since no certificates exist on disk")
+	}
+	if ctx.Err() != nil {
+		t.Fatalf("RenewAllViaAPI with maxConcurrent<=0 deadlocked: %v", ctx.Err())
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ExpirationThreshold classifies how urgently a certificate needs attention
+// based on its remaining validity.
+type ExpirationThreshold int
+
+const (
+	// ExpirationInfo means the certificate is still comfortably valid.
+	ExpirationInfo ExpirationThreshold = iota
+	// ExpirationWarning means the certificate is approaching expiration and
+	// should be renewed soon.
+	ExpirationWarning
+	// ExpirationCritical means the certificate will expire imminently.
+	ExpirationCritical
+	// ExpirationExpired means the certificate has already expired.
+	ExpirationExpired
+)
+
+// String returns the human-readable name of the threshold.
+func (t ExpirationThreshold) String() string {
+	switch t {
+	case ExpirationWarning:
+		return "WARNING"
+	case ExpirationCritical:
+		return "CRITICAL"
+	case ExpirationExpired:
+		return "EXPIRED"
+	default:
+		return "INFO"
+	}
+}
+
+// Default thresholds used to classify certificate expiration. They mirror
+// the informal guidance already given by `kubeadm certs check-expiration`,
+// turning it into re-usable, alertable classifications.
+const (
+	WarningThreshold  = 90 * 24 * time.Hour
+	CriticalThreshold = 21 * 24 * time.Hour
+)
+
+// CertExpirationInfo describes the expiration status of a single managed
+// certificate or CA.
+type CertExpirationInfo struct {
+	// Name is the handler name (e.g. "apiserver", "etcd/server").
+	Name string
+
+	// CAName is the name of the CA that signs this certificate, empty for CAs themselves.
+	CAName string
+
+	// IsCA is true when this entry describes a certificate authority.
+	IsCA bool
+
+	Subject   string
+	Issuer    string
+	NotAfter  time.Time
+	Threshold ExpirationThreshold
+}
+
+// classify buckets the remaining validity of a certificate into a threshold.
+func classify(notAfter time.Time, now time.Time) ExpirationThreshold {
+	remaining := notAfter.Sub(now)
+	switch {
+	case remaining <= 0:
+		return ExpirationExpired
+	case remaining < CriticalThreshold:
+		return ExpirationCritical
+	case remaining < WarningThreshold:
+		return ExpirationWarning
+	default:
+		return ExpirationInfo
+	}
+}
+
+// CheckExpiration inspects every certificate and CA known to the Manager and
+// returns their current expiration classification, relative to now.
+func (rm *Manager) CheckExpiration(now time.Time) ([]CertExpirationInfo, error) {
+	infos := make([]CertExpirationInfo, 0, len(rm.certificates)+len(rm.cas))
+
+	for _, handler := range rm.Certificates() {
+		if !handler.readwriter.Exists() {
+			continue
+		}
+
+		cert, err := handler.readwriter.Read()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read certificate %s while checking expiration", handler.Name)
+		}
+
+		infos = append(infos, CertExpirationInfo{
+			Name:      handler.Name,
+			CAName:    handler.CABaseName,
+			Subject:   cert.Subject.CommonName,
+			Issuer:    cert.Issuer.CommonName,
+			NotAfter:  cert.NotAfter,
+			Threshold: classify(cert.NotAfter, now),
+		})
+	}
+
+	for _, handler := range rm.CAs() {
+		if !handler.readwriter.Exists() {
+			continue
+		}
+
+		cert, err := handler.readwriter.Read()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read CA %s while checking expiration", handler.Name)
+		}
+
+		infos = append(infos, CertExpirationInfo{
+			Name:      handler.Name,
+			IsCA:      true,
+			Subject:   cert.Subject.CommonName,
+			Issuer:    cert.Issuer.CommonName,
+			NotAfter:  cert.NotAfter,
+			Threshold: classify(cert.NotAfter, now),
+		})
+	}
+
+	return infos, nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+)
+
+func TestClassify(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		notAfter time.Time
+		want     ExpirationThreshold
+	}{
+		{
+			name:     "comfortably valid",
+			notAfter: now.Add(120 * 24 * time.Hour),
+			want:     ExpirationInfo,
+		},
+		{
+			name:     "just above the warning threshold",
+			notAfter: now.Add(WarningThreshold + time.Minute),
+			want:     ExpirationInfo,
+		},
+		{
+			name:     "just inside the warning threshold",
+			notAfter: now.Add(WarningThreshold - time.Minute),
+			want:     ExpirationWarning,
+		},
+		{
+			name:     "just above the critical threshold",
+			notAfter: now.Add(CriticalThreshold + time.Minute),
+			want:     ExpirationWarning,
+		},
+		{
+			name:     "just inside the critical threshold",
+			notAfter: now.Add(CriticalThreshold - time.Minute),
+			want:     ExpirationCritical,
+		},
+		{
+			name:     "expiring exactly now",
+			notAfter: now,
+			want:     ExpirationExpired,
+		},
+		{
+			name:     "already expired",
+			notAfter: now.Add(-time.Hour),
+			want:     ExpirationExpired,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := classify(test.notAfter, now); got != test.want {
+				t.Errorf("classify(%s, %s) = %s, want %s", test.notAfter, now, got, test.want)
+			}
+		})
+	}
+}
+
+func TestCheckExpiration(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	warnCert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "warn-cert"},
+		Issuer:   pkix.Name{CommonName: "ca"},
+		NotAfter: now.Add(30 * 24 * time.Hour),
+	}
+	infoCert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "info-cert"},
+		Issuer:   pkix.Name{CommonName: "ca"},
+		NotAfter: now.Add(200 * 24 * time.Hour),
+	}
+	caCert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "ca"},
+		Issuer:   pkix.Name{CommonName: "ca"},
+		NotAfter: now.Add(-time.Hour),
+	}
+
+	rm := &Manager{
+		certificates: map[string]*CertificateRenewHandler{
+			"warn-cert": {
+				Name:       "warn-cert",
+				CABaseName: "ca",
+				readwriter: fakecertificateReadWriter{exist: true, cert: warnCert},
+			},
+			"info-cert": {
+				Name:       "info-cert",
+				CABaseName: "ca",
+				readwriter: fakecertificateReadWriter{exist: true, cert: infoCert},
+			},
+			"missing-cert": {
+				Name:       "missing-cert",
+				CABaseName: "ca",
+				readwriter: fakecertificateReadWriter{exist: false},
+			},
+		},
+		cas: map[string]*CAExpirationHandler{
+			"ca": {
+				Name:       "ca",
+				readwriter: fakecertificateReadWriter{exist: true, cert: caCert},
+			},
+		},
+	}
+
+	infos, err := rm.CheckExpiration(now)
+	if err != nil {
+		t.Fatalf("CheckExpiration returned an error: %v", err)
+	}
+
+	got := map[string]ExpirationThreshold{}
+	for _, info := range infos {
+		got[info.Name] = info.Threshold
+	}
+
+	if len(infos) != 3 {
+		t.Fatalf("expected 3 entries (missing-cert has no file and should be skipped), got %d: %+v", len(infos), infos)
+	}
+	if _, ok := got["missing-cert"]; ok {
+		t.Errorf("expected missing-cert to be skipped since it does not exist on disk")
+	}
+	if threshold := got["warn-cert"]; threshold != ExpirationWarning {
+		t.Errorf("expected warn-cert to be classified as %s, got %s", ExpirationWarning, threshold)
+	}
+	if threshold := got["info-cert"]; threshold != ExpirationInfo {
+		t.Errorf("expected info-cert to be classified as %s, got %s", ExpirationInfo, threshold)
+	}
+	if threshold := got["ca"]; threshold != ExpirationExpired {
+		t.Errorf("expected ca to be classified as %s, got %s", ExpirationExpired, threshold)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"crypto"
+	"crypto/x509"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	clientcertutil "k8s.io/client-go/util/cert"
+	clientkeyutil "k8s.io/client-go/util/keyutil"
+
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+)
+
+// writeTestCertificate creates a certificate for the given organization,
+// signed by caCert/caKey, writes it (and its key) to dir as <name>.crt/.key
+// and returns it.
+func writeTestCertificate(t *testing.T, dir, name string, caCert *x509.Certificate, caKey crypto.Signer, organization []string) *x509.Certificate {
+	t.Helper()
+
+	cert, key, err := pkiutil.NewCertAndKey(caCert, caKey, makeTestCertConfig(organization))
+	if err != nil {
+		t.Fatalf("couldn't generate certificate %s: %v", name, err)
+	}
+
+	if err := pkiutil.WriteCertAndKey(dir, name, cert, key); err != nil {
+		t.Fatalf("couldn't write out certificate %s to %s", name, dir)
+	}
+
+	return cert
+}
+
+// writeTestKubeconfig creates a client certificate for testCertOrganization,
+// signed by caCert/caKey, embeds it into a kubeconfig file named <name> in
+// dir and returns the embedded certificate.
+func writeTestKubeconfig(t *testing.T, dir, name string, caCert *x509.Certificate, caKey crypto.Signer) *x509.Certificate {
+	t.Helper()
+
+	cert, key, err := pkiutil.NewCertAndKey(caCert, caKey, makeTestCertConfig(testCertOrganization))
+	if err != nil {
+		t.Fatalf("couldn't generate certificate for kubeconfig %s: %v", name, err)
+	}
+
+	keyPEM, err := clientkeyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		t.Fatalf("couldn't marshal private key for kubeconfig %s: %v", name, err)
+	}
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters["test-cluster"] = &clientcmdapi.Cluster{
+		Server:                   "https://127.0.0.1:6443",
+		CertificateAuthorityData: clientcertutil.EncodeCertPEM(caCert),
+	}
+	config.AuthInfos["test-user"] = &clientcmdapi.AuthInfo{
+		ClientCertificateData: clientcertutil.EncodeCertPEM(cert),
+		ClientKeyData:         keyPEM,
+	}
+	config.Contexts["test-context"] = &clientcmdapi.Context{
+		Cluster:  "test-cluster",
+		AuthInfo: "test-user",
+	}
+	config.CurrentContext = "test-context"
+
+	if err := clientcmd.WriteToFile(*config, filepath.Join(dir, name)); err != nil {
+		t.Fatalf("couldn't write out kubeconfig %s to %s", name, dir)
+	}
+
+	return cert
+}
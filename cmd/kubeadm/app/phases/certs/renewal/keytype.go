@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+)
+
+// detectEncryptionAlgorithm maps the key type of an already-issued
+// certificate back to the kubeadmapi.EncryptionAlgorithmType that produced
+// it, so RenewUsingLocalCA can keep renewing with the same key type instead
+// of silently falling back to the default.
+func detectEncryptionAlgorithm(cert *x509.Certificate) (kubeadmapi.EncryptionAlgorithmType, error) {
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return kubeadmapi.EncryptionAlgorithmRSA, nil
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256():
+			return kubeadmapi.EncryptionAlgorithmECDSAP256, nil
+		case elliptic.P384():
+			return kubeadmapi.EncryptionAlgorithmECDSAP384, nil
+		default:
+			return "", errors.Errorf("unsupported ECDSA curve %s", pub.Curve.Params().Name)
+		}
+	case ed25519.PublicKey:
+		return kubeadmapi.EncryptionAlgorithmEd25519, nil
+	default:
+		return "", errors.Errorf("unsupported public key type %T", pub)
+	}
+}
+
+// RenewWithKeyType renews a certificate using the local CA, migrating it to
+// the given key type instead of preserving its current one. This lets
+// `kubeadm certs renew --key-type=ecdsa-p256` move a certificate from RSA to
+// ECDSA (or vice versa) across a renewal.
+func (rm *Manager) RenewWithKeyType(name string, kt kubeadmapi.EncryptionAlgorithmType) (bool, error) {
+	handler, ok := rm.certificates[name]
+	if !ok {
+		return false, errors.Errorf("%s is not a known certificate", name)
+	}
+
+	if rm.keyStorage.isKMSBacked(name) {
+		return false, errors.Errorf("%s's private key is held by a remote signer; migrating its key type locally would defeat that and is not supported", name)
+	}
+
+	cert, err := handler.readwriter.Read()
+	if err != nil {
+		return false, err
+	}
+
+	cfg := certToConfig(cert)
+	applyOrganizationQuirks(handler, cfg)
+
+	caCert, caKey, err := pkiutil.TryLoadCertAndKeyFromDisk(rm.certificatesDir, handler.CABaseName)
+	if err != nil {
+		return false, errors.Wrapf(err, "couldn't load CA certificate %s", handler.CABaseName)
+	}
+
+	newCert, newKey, err := pkiutil.NewCertAndKey(caCert, caKey, &pkiutil.CertConfig{
+		Config:              *cfg,
+		EncryptionAlgorithm: kt,
+	})
+	if err != nil {
+		recordRenewal(name, err)
+		return false, err
+	}
+
+	err = handler.readwriter.Write(newCert, newKey)
+	recordRenewal(name, err)
+	return false, err
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"crypto/x509"
+	"os"
+	"testing"
+	"time"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	certtestutil "k8s.io/kubernetes/cmd/kubeadm/app/util/certs"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+	testutil "k8s.io/kubernetes/cmd/kubeadm/test"
+)
+
+func TestRenewWithKeyType(t *testing.T) {
+	dir := testutil.SetupTempDir(t)
+	defer os.RemoveAll(dir)
+
+	if err := pkiutil.WriteCertAndKey(dir, "ca", testCACert, testCAKey); err != nil {
+		t.Fatalf("couldn't write out CA certificate to %s", dir)
+	}
+
+	cfg := &kubeadmapi.ClusterConfiguration{
+		Etcd:            kubeadmapi.Etcd{External: &kubeadmapi.ExternalEtcd{}},
+		CertificatesDir: dir,
+	}
+	rm, err := NewManager(cfg, dir)
+	if err != nil {
+		t.Fatalf("Failed to create the certificate renewal manager: %v", err)
+	}
+
+	tests := []struct {
+		name              string
+		keyType           kubeadmapi.EncryptionAlgorithmType
+		expectedPublicKey x509.PublicKeyAlgorithm
+	}{
+		{
+			name:              "renew into RSA",
+			keyType:           kubeadmapi.EncryptionAlgorithmRSA,
+			expectedPublicKey: x509.RSA,
+		},
+		{
+			name:              "renew into ECDSA P-256",
+			keyType:           kubeadmapi.EncryptionAlgorithmECDSAP256,
+			expectedPublicKey: x509.ECDSA,
+		},
+		{
+			name:              "renew into Ed25519",
+			keyType:           kubeadmapi.EncryptionAlgorithmEd25519,
+			expectedPublicKey: x509.Ed25519,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cert := writeTestCertificate(t, dir, "apiserver", testCACert, testCAKey, testCertOrganization)
+
+			time.Sleep(1 * time.Second)
+
+			if _, err := rm.RenewWithKeyType("apiserver", test.keyType); err != nil {
+				t.Fatalf("error renewing certificate: %v", err)
+			}
+
+			newCert, err := rm.certificates["apiserver"].readwriter.Read()
+			if err != nil {
+				t.Fatalf("error reading renewed certificate: %v", err)
+			}
+
+			if newCert.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				t.Fatal("expected new certificate, but renewed certificate has same serial number")
+			}
+
+			if newCert.PublicKeyAlgorithm != test.expectedPublicKey {
+				t.Errorf("expected public key algorithm %v, got %v", test.expectedPublicKey, newCert.PublicKeyAlgorithm)
+			}
+
+			certtestutil.AssertCertificateIsSignedByCa(t, newCert, testCACert)
+		})
+	}
+}
+
+func TestRenewWithKeyTypeRefusesKMSBacked(t *testing.T) {
+	dir := testutil.SetupTempDir(t)
+	defer os.RemoveAll(dir)
+
+	if err := pkiutil.WriteCertAndKey(dir, "ca", testCACert, testCAKey); err != nil {
+		t.Fatalf("couldn't write out CA certificate to %s", dir)
+	}
+
+	cfg := &kubeadmapi.ClusterConfiguration{
+		Etcd:            kubeadmapi.Etcd{External: &kubeadmapi.ExternalEtcd{}},
+		CertificatesDir: dir,
+	}
+	rm, err := NewManager(cfg, dir)
+	if err != nil {
+		t.Fatalf("Failed to create the certificate renewal manager: %v", err)
+	}
+
+	rm.SetKeyStorage(&KeyStorage{KMSBacked: map[string]bool{"apiserver": true}})
+
+	writeTestCertificate(t, dir, "apiserver", testCACert, testCAKey, testCertOrganization)
+
+	if _, err := rm.RenewWithKeyType("apiserver", kubeadmapi.EncryptionAlgorithmECDSAP256); err == nil {
+		t.Fatal("expected RenewWithKeyType to refuse a KMS-backed certificate, got no error")
+	}
+}
+
+func TestDetectEncryptionAlgorithm(t *testing.T) {
+	dir := testutil.SetupTempDir(t)
+	defer os.RemoveAll(dir)
+
+	cert := writeTestCertificate(t, dir, "apiserver", testCACert, testCAKey, testCertOrganization)
+
+	kt, err := detectEncryptionAlgorithm(cert)
+	if err != nil {
+		t.Fatalf("unexpected error detecting the key algorithm: %v", err)
+	}
+
+	if kt != kubeadmapi.EncryptionAlgorithmRSA {
+		t.Errorf("expected %v, got %v", kubeadmapi.EncryptionAlgorithmRSA, kt)
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+)
+
+// SignerProvider resolves the crypto.Signer that should back a given managed
+// certificate, without ever bringing the private key material into kubeadm's
+// process memory as a serializable *rsa.PrivateKey/*ecdsa.PrivateKey - the
+// Sign operation is delegated to whatever holds the key (an HSM via PKCS#11,
+// or a cloud KMS).
+type SignerProvider interface {
+	// Signer returns the crypto.Signer backing the named certificate, or
+	// (nil, false) if that certificate is not KMS/HSM-backed.
+	Signer(name string) (crypto.Signer, bool, error)
+}
+
+// KeyStorage marks which managed certificates have their private key held
+// outside of kubeadm's certificates directory, and provides the
+// SignerProvider used to reach it. Like RenewalPolicy, it is not a field of
+// ClusterConfiguration: it is installed on a Manager with SetKeyStorage.
+type KeyStorage struct {
+	// KMSBacked lists the certificate names (e.g. "apiserver") whose key is
+	// remote; kubeadm never writes a .key file for them.
+	KMSBacked map[string]bool
+
+	// Provider resolves the crypto.Signer for each name in KMSBacked.
+	Provider SignerProvider
+}
+
+// isKMSBacked reports whether name's private key lives outside kubeadm.
+func (ks *KeyStorage) isKMSBacked(name string) bool {
+	return ks != nil && ks.KMSBacked[name]
+}
+
+// kmsRenewer renews a certificate whose key is held by a remote signer: it
+// builds a new certificate bound to the existing remote public key and signs
+// it with the local CA, without ever generating or reading a local private
+// key. Renew's returned crypto.Signer is always nil, which readwriter.Write
+// interprets as "the key file is unchanged".
+type kmsRenewer struct {
+	certificatesDir string
+	provider        SignerProvider
+}
+
+func (r *kmsRenewer) Renew(handler *CertificateRenewHandler) (*x509.Certificate, crypto.Signer, error) {
+	if r.provider == nil {
+		return nil, nil, errors.Errorf("%s is marked KMS-backed but no SignerProvider is configured; see KeyStorage.Provider", handler.Name)
+	}
+
+	cert, err := handler.readwriter.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg := certToConfig(cert)
+	applyOrganizationQuirks(handler, cfg)
+
+	signer, ok, err := r.provider.Signer(handler.Name)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to resolve the remote signer for %s", handler.Name)
+	}
+	if !ok {
+		return nil, nil, errors.Errorf("no remote signer registered for %s", handler.Name)
+	}
+
+	caCert, caKey, err := pkiutil.TryLoadCertAndKeyFromDisk(r.certificatesDir, handler.CABaseName)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "couldn't load CA certificate %s", handler.CABaseName)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate a certificate serial number")
+	}
+
+	template := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:   cfg.CommonName,
+			Organization: cfg.Organization,
+		},
+		DNSNames:     cfg.AltNames.DNSNames,
+		IPAddresses:  cfg.AltNames.IPs,
+		ExtKeyUsage:  cfg.Usages,
+		KeyUsage:     cert.KeyUsage,
+		SerialNumber: serial,
+		NotBefore:    time.Now().UTC(),
+		NotAfter:     time.Now().UTC().Add(cert.NotAfter.Sub(cert.NotBefore)),
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, caCert, signer.Public(), caKey)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to sign the renewed certificate for %s", handler.Name)
+	}
+
+	newCert, err := x509.ParseCertificate(derBytes)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to parse the renewed certificate for %s", handler.Name)
+	}
+
+	return newCert, nil, nil
+}
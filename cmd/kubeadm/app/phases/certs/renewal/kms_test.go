@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	certtestutil "k8s.io/kubernetes/cmd/kubeadm/app/util/certs"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+	testutil "k8s.io/kubernetes/cmd/kubeadm/test"
+)
+
+// fakeHSM is an in-memory crypto.Signer standing in for a PKCS#11 token or
+// cloud KMS key: the private key never leaves it.
+type fakeHSM struct {
+	key *ecdsa.PrivateKey
+}
+
+func newFakeHSM(t *testing.T) *fakeHSM {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate fake HSM key: %v", err)
+	}
+	return &fakeHSM{key: key}
+}
+
+func (h *fakeHSM) Public() crypto.PublicKey {
+	return &h.key.PublicKey
+}
+
+func (h *fakeHSM) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return h.key.Sign(rand, digest, opts)
+}
+
+// fakeSignerProvider resolves a fixed in-memory HSM signer for a single certificate name.
+type fakeSignerProvider struct {
+	name   string
+	signer crypto.Signer
+}
+
+func (p *fakeSignerProvider) Signer(name string) (crypto.Signer, bool, error) {
+	if name != p.name {
+		return nil, false, nil
+	}
+	return p.signer, true, nil
+}
+
+func TestRenewUsingKMSBackedSignerWithoutProvider(t *testing.T) {
+	dir := testutil.SetupTempDir(t)
+	defer os.RemoveAll(dir)
+
+	if err := pkiutil.WriteCertAndKey(dir, "ca", testCACert, testCAKey); err != nil {
+		t.Fatalf("couldn't write out CA certificate to %s", dir)
+	}
+
+	cfg := &kubeadmapi.ClusterConfiguration{
+		Etcd:            kubeadmapi.Etcd{External: &kubeadmapi.ExternalEtcd{}},
+		CertificatesDir: dir,
+	}
+	rm, err := NewManager(cfg, dir)
+	if err != nil {
+		t.Fatalf("Failed to create the certificate renewal manager: %v", err)
+	}
+
+	rm.SetKeyStorage(&KeyStorage{KMSBacked: map[string]bool{"apiserver": true}})
+
+	writeTestCertificate(t, dir, "apiserver", testCACert, testCAKey, testCertOrganization)
+
+	if _, err := rm.RenewWithPolicy("apiserver"); err == nil {
+		t.Fatal("expected an error renewing a KMS-backed certificate with no Provider configured, got none")
+	}
+}
+
+func TestRenewUsingKMSBackedSigner(t *testing.T) {
+	dir := testutil.SetupTempDir(t)
+	defer os.RemoveAll(dir)
+
+	if err := pkiutil.WriteCertAndKey(dir, "ca", testCACert, testCAKey); err != nil {
+		t.Fatalf("couldn't write out CA certificate to %s", dir)
+	}
+
+	cfg := &kubeadmapi.ClusterConfiguration{
+		Etcd:            kubeadmapi.Etcd{External: &kubeadmapi.ExternalEtcd{}},
+		CertificatesDir: dir,
+	}
+	rm, err := NewManager(cfg, dir)
+	if err != nil {
+		t.Fatalf("Failed to create the certificate renewal manager: %v", err)
+	}
+
+	hsm := newFakeHSM(t)
+	rm.SetKeyStorage(&KeyStorage{
+		KMSBacked: map[string]bool{"apiserver": true},
+		Provider:  &fakeSignerProvider{name: "apiserver", signer: hsm},
+	})
+
+	cert := writeTestCertificate(t, dir, "apiserver", testCACert, testCAKey, testCertOrganization)
+
+	keyPath := filepath.Join(dir, "apiserver.key")
+	originalKeyBytes, err := os.ReadFile(keyPath)
+	if err != nil {
+		t.Fatalf("couldn't read the pre-existing key file %s: %v", keyPath, err)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	if _, err := rm.RenewWithPolicy("apiserver"); err != nil {
+		t.Fatalf("error renewing certificate: %v", err)
+	}
+
+	newCert, err := rm.certificates["apiserver"].readwriter.Read()
+	if err != nil {
+		t.Fatalf("error reading renewed certificate: %v", err)
+	}
+
+	if newCert.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+		t.Fatal("expected new certificate, but renewed certificate has same serial number")
+	}
+
+	certtestutil.AssertCertificateIsSignedByCa(t, newCert, testCACert)
+
+	newPub, ok := newCert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		t.Fatalf("expected the renewed certificate to carry the HSM-held public key, got %T", newCert.PublicKey)
+	}
+	if !newPub.Equal(&hsm.key.PublicKey) {
+		t.Fatal("expected the renewed certificate to carry the HSM-held public key")
+	}
+
+	newKeyBytes, err := os.ReadFile(keyPath)
+	if err != nil {
+		t.Fatalf("couldn't read %s after renewal: %v", keyPath, err)
+	}
+	if string(newKeyBytes) != string(originalKeyBytes) {
+		t.Fatal("expected the .key file to be left untouched for a KMS-backed certificate")
+	}
+}
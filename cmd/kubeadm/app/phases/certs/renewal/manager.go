@@ -0,0 +1,379 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"crypto/x509"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	certutil "k8s.io/client-go/util/cert"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+)
+
+// Manager can be used to coordinate certificate renewal and related processes,
+// like CSR generation or checking certificate expiration
+type Manager struct {
+	certificatesDir string
+	certificates    map[string]*CertificateRenewHandler
+	cas             map[string]*CAExpirationHandler
+	renewalPolicy   *RenewalPolicy
+	keyStorage      *KeyStorage
+}
+
+// CertificateRenewHandler defines required info for renewing a certificate
+type CertificateRenewHandler struct {
+	// Name of the certificate to be used for user facing UI
+	Name string
+
+	// LongName of the certificate to be used for user facing UI
+	LongName string
+
+	// FileName defines the name (qualified by the K8s prefix) used for the certificate/key files
+	FileName string
+
+	// CABaseName defines the base name for the CA that signs the certificate
+	CABaseName string
+
+	readwriter certificateReadWriter
+}
+
+// CAExpirationHandler defines required info for checking CA expiration
+type CAExpirationHandler struct {
+	// Name of the CA to be used for user facing UI
+	Name string
+
+	// LongName of the CA to be used for user facing UI
+	LongName string
+
+	// FileName defines the name (qualified by the K8s prefix) used for the CA certificate/key files
+	FileName string
+
+	readwriter certificateReadWriter
+}
+
+// NewManager returns a new certificate renewal manager
+func NewManager(cfg *kubeadmapi.ClusterConfiguration, kubernetesDir string) (*Manager, error) {
+	rm := &Manager{
+		certificatesDir: cfg.CertificatesDir,
+		certificates:    map[string]*CertificateRenewHandler{},
+		cas:             map[string]*CAExpirationHandler{},
+	}
+
+	rm.certificates["admin.conf"] = &CertificateRenewHandler{
+		Name:       "admin.conf",
+		LongName:   "certificate embedded in the kubeconfig file for the admin to use and for kubeadm itself",
+		FileName:   kubeadmconstants.AdminKubeConfigFileName,
+		CABaseName: kubeadmconstants.CACertAndKeyBaseName,
+		readwriter: newKubeconfigReadWriter(kubernetesDir, kubeadmconstants.AdminKubeConfigFileName, rm.certificatesDir, kubeadmconstants.CACertAndKeyBaseName),
+	}
+
+	rm.certificates["super-admin.conf"] = &CertificateRenewHandler{
+		Name:       "super-admin.conf",
+		LongName:   "certificate embedded in the kubeconfig file for the super-admin to use",
+		FileName:   kubeadmconstants.SuperAdminKubeConfigFileName,
+		CABaseName: kubeadmconstants.CACertAndKeyBaseName,
+		readwriter: newKubeconfigReadWriter(kubernetesDir, kubeadmconstants.SuperAdminKubeConfigFileName, rm.certificatesDir, kubeadmconstants.CACertAndKeyBaseName),
+	}
+
+	rm.certificates["scheduler.conf"] = &CertificateRenewHandler{
+		Name:       "scheduler.conf",
+		LongName:   "certificate embedded in the kubeconfig file for the scheduler manager to use",
+		FileName:   kubeadmconstants.SchedulerKubeConfigFileName,
+		CABaseName: kubeadmconstants.CACertAndKeyBaseName,
+		readwriter: newKubeconfigReadWriter(kubernetesDir, kubeadmconstants.SchedulerKubeConfigFileName, rm.certificatesDir, kubeadmconstants.CACertAndKeyBaseName),
+	}
+
+	rm.certificates["controller-manager.conf"] = &CertificateRenewHandler{
+		Name:       "controller-manager.conf",
+		LongName:   "certificate embedded in the kubeconfig file for the controller manager to use",
+		FileName:   kubeadmconstants.ControllerManagerKubeConfigFileName,
+		CABaseName: kubeadmconstants.CACertAndKeyBaseName,
+		readwriter: newKubeconfigReadWriter(kubernetesDir, kubeadmconstants.ControllerManagerKubeConfigFileName, rm.certificatesDir, kubeadmconstants.CACertAndKeyBaseName),
+	}
+
+	rm.certificates[kubeadmconstants.APIServerCertAndKeyBaseName] = &CertificateRenewHandler{
+		Name:       kubeadmconstants.APIServerCertAndKeyBaseName,
+		LongName:   "certificate for serving the Kubernetes API",
+		FileName:   kubeadmconstants.APIServerCertAndKeyBaseName,
+		CABaseName: kubeadmconstants.CACertAndKeyBaseName,
+		readwriter: newPKICertificateReadWriter(rm.certificatesDir, kubeadmconstants.APIServerCertAndKeyBaseName),
+	}
+
+	rm.certificates[kubeadmconstants.APIServerKubeletClientCertAndKeyBaseName] = &CertificateRenewHandler{
+		Name:       kubeadmconstants.APIServerKubeletClientCertAndKeyBaseName,
+		LongName:   "client certificate for the API server to connect to the kubelet",
+		FileName:   kubeadmconstants.APIServerKubeletClientCertAndKeyBaseName,
+		CABaseName: kubeadmconstants.CACertAndKeyBaseName,
+		readwriter: newPKICertificateReadWriter(rm.certificatesDir, kubeadmconstants.APIServerKubeletClientCertAndKeyBaseName),
+	}
+
+	rm.certificates[kubeadmconstants.FrontProxyClientCertAndKeyBaseName] = &CertificateRenewHandler{
+		Name:       kubeadmconstants.FrontProxyClientCertAndKeyBaseName,
+		LongName:   "client certificate for the front proxy",
+		FileName:   kubeadmconstants.FrontProxyClientCertAndKeyBaseName,
+		CABaseName: kubeadmconstants.FrontProxyCACertAndKeyBaseName,
+		readwriter: newPKICertificateReadWriter(rm.certificatesDir, kubeadmconstants.FrontProxyClientCertAndKeyBaseName),
+	}
+
+	if cfg.Etcd.External == nil {
+		rm.certificates[kubeadmconstants.EtcdServerCertAndKeyBaseName] = &CertificateRenewHandler{
+			Name:       kubeadmconstants.EtcdServerCertAndKeyBaseName,
+			LongName:   "certificate for serving etcd",
+			FileName:   kubeadmconstants.EtcdServerCertAndKeyBaseName,
+			CABaseName: kubeadmconstants.EtcdCACertAndKeyBaseName,
+			readwriter: newPKICertificateReadWriter(filepath.Join(rm.certificatesDir, kubeadmconstants.EtcdDirName), kubeadmconstants.EtcdServerCertAndKeyBaseName),
+		}
+
+		rm.certificates[kubeadmconstants.EtcdPeerCertAndKeyBaseName] = &CertificateRenewHandler{
+			Name:       kubeadmconstants.EtcdPeerCertAndKeyBaseName,
+			LongName:   "certificate for etcd nodes to communicate with each other",
+			FileName:   kubeadmconstants.EtcdPeerCertAndKeyBaseName,
+			CABaseName: kubeadmconstants.EtcdCACertAndKeyBaseName,
+			readwriter: newPKICertificateReadWriter(filepath.Join(rm.certificatesDir, kubeadmconstants.EtcdDirName), kubeadmconstants.EtcdPeerCertAndKeyBaseName),
+		}
+
+		rm.certificates[kubeadmconstants.EtcdHealthcheckClientCertAndKeyBaseName] = &CertificateRenewHandler{
+			Name:       kubeadmconstants.EtcdHealthcheckClientCertAndKeyBaseName,
+			LongName:   "client certificate for liveness probes to healthcheck etcd",
+			FileName:   kubeadmconstants.EtcdHealthcheckClientCertAndKeyBaseName,
+			CABaseName: kubeadmconstants.EtcdCACertAndKeyBaseName,
+			readwriter: newPKICertificateReadWriter(filepath.Join(rm.certificatesDir, kubeadmconstants.EtcdDirName), kubeadmconstants.EtcdHealthcheckClientCertAndKeyBaseName),
+		}
+
+		rm.certificates[kubeadmconstants.APIServerEtcdClientCertAndKeyBaseName] = &CertificateRenewHandler{
+			Name:       kubeadmconstants.APIServerEtcdClientCertAndKeyBaseName,
+			LongName:   "client certificate for the API server to connect to etcd",
+			FileName:   kubeadmconstants.APIServerEtcdClientCertAndKeyBaseName,
+			CABaseName: kubeadmconstants.EtcdCACertAndKeyBaseName,
+			readwriter: newPKICertificateReadWriter(rm.certificatesDir, kubeadmconstants.APIServerEtcdClientCertAndKeyBaseName),
+		}
+	}
+
+	rm.cas[kubeadmconstants.CACertAndKeyBaseName] = &CAExpirationHandler{
+		Name:       kubeadmconstants.CACertAndKeyBaseName,
+		LongName:   "certificate authority for the cluster",
+		FileName:   kubeadmconstants.CACertAndKeyBaseName,
+		readwriter: newPKICertificateReadWriter(rm.certificatesDir, kubeadmconstants.CACertAndKeyBaseName),
+	}
+
+	rm.cas[kubeadmconstants.FrontProxyCACertAndKeyBaseName] = &CAExpirationHandler{
+		Name:       kubeadmconstants.FrontProxyCACertAndKeyBaseName,
+		LongName:   "certificate authority for front-proxy",
+		FileName:   kubeadmconstants.FrontProxyCACertAndKeyBaseName,
+		readwriter: newPKICertificateReadWriter(rm.certificatesDir, kubeadmconstants.FrontProxyCACertAndKeyBaseName),
+	}
+
+	if cfg.Etcd.External == nil {
+		rm.cas[kubeadmconstants.EtcdCACertAndKeyBaseName] = &CAExpirationHandler{
+			Name:       kubeadmconstants.EtcdCACertAndKeyBaseName,
+			LongName:   "certificate authority for local etcd",
+			FileName:   kubeadmconstants.EtcdCACertAndKeyBaseName,
+			readwriter: newPKICertificateReadWriter(filepath.Join(rm.certificatesDir, kubeadmconstants.EtcdDirName), kubeadmconstants.EtcdCACertAndKeyBaseName),
+		}
+	}
+
+	return rm, nil
+}
+
+// SetRenewalPolicy configures the per-certificate ACME backend used by
+// RenewWithPolicy. KMS-backed certificates are configured separately with
+// SetKeyStorage. Certificates named by neither keep renewing through the
+// local CA.
+func (rm *Manager) SetRenewalPolicy(policy *RenewalPolicy) {
+	rm.renewalPolicy = policy
+}
+
+// RenewWithPolicy renews a certificate using the backend selected for it by
+// the Manager's RenewalPolicy (see SetRenewalPolicy), falling back to the
+// local CA when no backend is configured for this certificate name.
+func (rm *Manager) RenewWithPolicy(name string) (bool, error) {
+	handler, ok := rm.certificates[name]
+	if !ok {
+		return false, errors.Errorf("%s is not a known certificate", name)
+	}
+
+	newCert, newKey, err := rm.renewerFor(handler).Renew(handler)
+	if err != nil {
+		recordRenewal(name, err)
+		return false, err
+	}
+
+	err = handler.readwriter.Write(newCert, newKey)
+	recordRenewal(name, err)
+	return false, err
+}
+
+// Certificates returns the list of certificates controlled by this Manager
+func (rm *Manager) Certificates() []*CertificateRenewHandler {
+	certificates := []*CertificateRenewHandler{}
+	for _, h := range rm.certificates {
+		certificates = append(certificates, h)
+	}
+
+	sort.Slice(certificates, func(i, j int) bool {
+		return certificates[i].Name < certificates[j].Name
+	})
+
+	return certificates
+}
+
+// CAs returns the list of CAs controlled by this Manager
+func (rm *Manager) CAs() []*CAExpirationHandler {
+	cas := []*CAExpirationHandler{}
+	for _, h := range rm.cas {
+		cas = append(cas, h)
+	}
+
+	sort.Slice(cas, func(i, j int) bool {
+		return cas[i].Name < cas[j].Name
+	})
+
+	return cas
+}
+
+// CertificateExists returns true if a certificate exists
+func (rm *Manager) CertificateExists(name string) (bool, error) {
+	handler, ok := rm.certificates[name]
+	if !ok {
+		return false, errors.Errorf("%s is not a known certificate", name)
+	}
+
+	return handler.readwriter.Exists(), nil
+}
+
+// CAExists returns true if a certificate authority exists
+func (rm *Manager) CAExists(name string) (bool, error) {
+	handler, ok := rm.cas[name]
+	if !ok {
+		return false, errors.Errorf("%s is not a known certificate authority", name)
+	}
+
+	return handler.readwriter.Exists(), nil
+}
+
+// RenewUsingLocalCA executes certificate renewal using local certificate authorities.
+// For PKI certificates, the parent CA key file stored in the certificates directory is used.
+// For certificates embedded in kubeconfig files, the CA linked to that certificate is used.
+func (rm *Manager) RenewUsingLocalCA(name string) (bool, error) {
+	handler, ok := rm.certificates[name]
+	if !ok {
+		return false, errors.Errorf("%s is not a known certificate", name)
+	}
+
+	newCert, newKey, err := (&localCARenewer{certificatesDir: rm.certificatesDir}).Renew(handler)
+	if err != nil {
+		recordRenewal(name, err)
+		return false, err
+	}
+
+	err = handler.readwriter.Write(newCert, newKey)
+	recordRenewal(name, err)
+	return false, err
+}
+
+// renewerFor returns the Renewer backend configured for the given certificate,
+// defaulting to the local CA when RenewalPolicy does not name a backend for it.
+func (rm *Manager) renewerFor(handler *CertificateRenewHandler) Renewer {
+	if rm.keyStorage.isKMSBacked(handler.Name) {
+		return &kmsRenewer{certificatesDir: rm.certificatesDir, provider: rm.keyStorage.Provider}
+	}
+	if rm.renewalPolicy != nil {
+		if backend, ok := rm.renewalPolicy.BackendFor(handler.Name); ok {
+			return backend
+		}
+	}
+	return &localCARenewer{certificatesDir: rm.certificatesDir}
+}
+
+// SetKeyStorage configures which certificates are backed by a remote signer
+// (PKCS#11 token or cloud KMS) rather than a local key file. Certificates
+// named in ks.KMSBacked renew through RenewWithPolicy without ever having
+// their private key read into kubeadm or written to disk.
+func (rm *Manager) SetKeyStorage(ks *KeyStorage) {
+	rm.keyStorage = ks
+}
+
+// CreateRenewCSR generates a CSR for a new certificate, to be signed out-of-band from kubeadm
+func (rm *Manager) CreateRenewCSR(name, outdir string) error {
+	handler, ok := rm.certificates[name]
+	if !ok {
+		return errors.Errorf("%s is not a known certificate", name)
+	}
+
+	cert, err := handler.readwriter.Read()
+	if err != nil {
+		return err
+	}
+
+	cfg := certToConfig(cert)
+
+	csr, key, err := pkiutil.NewCSRAndKey(&pkiutil.CertConfig{Config: *cfg})
+	if err != nil {
+		return err
+	}
+
+	if err := pkiutil.WriteKey(outdir, name, key); err != nil {
+		return errors.Wrapf(err, "failure while saving key for %s", name)
+	}
+
+	if err := pkiutil.WriteCSR(outdir, name, csr); err != nil {
+		return errors.Wrapf(err, "failure while saving CSR for %s", name)
+	}
+
+	return nil
+}
+
+// certToConfig translates the properties of an x509 certificate into a certutil.Config
+func certToConfig(cert *x509.Certificate) *certutil.Config {
+	return &certutil.Config{
+		CommonName:   cert.Subject.CommonName,
+		Organization: cert.Subject.Organization,
+		AltNames: certutil.AltNames{
+			IPs:      cert.IPAddresses,
+			DNSNames: cert.DNSNames,
+		},
+		Usages: cert.ExtKeyUsage,
+	}
+}
+
+func removeOrganization(orgs []string, toRemove string) []string {
+	result := []string{}
+	for _, org := range orgs {
+		if org != toRemove {
+			result = append(result, org)
+		}
+	}
+	return result
+}
+
+func replaceOrganization(orgs []string, old, new string) []string {
+	result := []string{}
+	for _, org := range orgs {
+		if org == old {
+			result = append(result, new)
+			continue
+		}
+		result = append(result, org)
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+var (
+	certificateExpirationSeconds = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Name:           "kubeadm_certificate_expiration_seconds",
+			Help:           "Seconds until the managed certificate expires, keyed by subject/issuer/filename/ca.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"subject", "issuer", "filename", "ca"},
+	)
+
+	certificateRenewalTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Name:           "kubeadm_certificate_renewal_total",
+			Help:           "Number of certificate renewal attempts performed by kubeadm, keyed by filename and result.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"filename", "result"},
+	)
+
+	registerMetricsOnce sync.Once
+)
+
+// registerMetrics registers the expiration gauge and renewal counter with the
+// process-wide legacy registry, so no second registry has to be wired up.
+// This package does not serve that registry over HTTP; whatever embeds it
+// must expose legacyregistry's handler for the metrics to be scraped.
+func registerMetrics() {
+	registerMetricsOnce.Do(func() {
+		legacyregistry.MustRegister(certificateExpirationSeconds)
+		legacyregistry.MustRegister(certificateRenewalTotal)
+	})
+}
+
+// recordExpiration publishes the expiration gauge for a single certificate or CA.
+func recordExpiration(info CertExpirationInfo, now time.Time) {
+	registerMetrics()
+	certificateExpirationSeconds.WithLabelValues(info.Subject, info.Issuer, info.Name, info.CAName).
+		Set(info.NotAfter.Sub(now).Seconds())
+}
+
+// recordRenewal increments the renewal success/failure counter for a certificate.
+func recordRenewal(name string, err error) {
+	registerMetrics()
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	certificateRenewalTotal.WithLabelValues(name, result).Inc()
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestRecordExpiration(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := now.Add(48 * time.Hour)
+
+	recordExpiration(CertExpirationInfo{
+		Name:     "apiserver",
+		CAName:   "ca",
+		Subject:  "kube-apiserver",
+		Issuer:   "kubernetes",
+		NotAfter: notAfter,
+	}, now)
+
+	metric := &dto.Metric{}
+	gauge := certificateExpirationSeconds.WithLabelValues("kube-apiserver", "kubernetes", "apiserver", "ca")
+	if err := gauge.Write(metric); err != nil {
+		t.Fatalf("couldn't read the gauge value: %v", err)
+	}
+
+	if got, want := metric.GetGauge().GetValue(), notAfter.Sub(now).Seconds(); got != want {
+		t.Errorf("expected the gauge to read %v seconds, got %v", want, got)
+	}
+}
+
+func TestRecordRenewal(t *testing.T) {
+	recordRenewal("apiserver", nil)
+	recordRenewal("apiserver", errors.New("boom"))
+
+	metric := &dto.Metric{}
+	counter := certificateRenewalTotal.WithLabelValues("apiserver", "failure")
+	if err := counter.Write(metric); err != nil {
+		t.Fatalf("couldn't read the counter value: %v", err)
+	}
+
+	if got := metric.GetCounter().GetValue(); got < 1 {
+		t.Errorf("expected the failure counter for apiserver to be at least 1, got %v", got)
+	}
+}
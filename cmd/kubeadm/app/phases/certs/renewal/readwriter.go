@@ -0,0 +1,186 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"crypto"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	clientcertutil "k8s.io/client-go/util/cert"
+	clientkeyutil "k8s.io/client-go/util/keyutil"
+
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+)
+
+// certificateReadWriter defines the behavior of a component that reads and
+// writes a certificate/key pair, either stored on disk as a PKI file pair or
+// embedded as client credentials in a kubeconfig file.
+type certificateReadWriter interface {
+	// Exists returns true if the certificate/key pair already exist
+	Exists() bool
+
+	// Read reads the current certificate
+	Read() (*x509.Certificate, error)
+
+	// Write saves the given certificate/key pair, replacing the current one
+	Write(*x509.Certificate, crypto.Signer) error
+}
+
+// pkiCertificateReadWriter reads and writes a certificate/key pair stored as
+// a <baseName>.crt / <baseName>.key file pair in a PKI directory
+type pkiCertificateReadWriter struct {
+	baseName string
+	dir      string
+}
+
+func newPKICertificateReadWriter(dir, baseName string) *pkiCertificateReadWriter {
+	return &pkiCertificateReadWriter{
+		baseName: baseName,
+		dir:      dir,
+	}
+}
+
+func (rw *pkiCertificateReadWriter) Exists() bool {
+	certificatePath, _ := pkiutil.PathsForCertAndKey(rw.dir, rw.baseName)
+	_, err := os.Stat(certificatePath)
+	return err == nil
+}
+
+func (rw *pkiCertificateReadWriter) Read() (*x509.Certificate, error) {
+	certificatePath, _ := pkiutil.PathsForCertAndKey(rw.dir, rw.baseName)
+	certs, err := clientcertutil.CertsFromFile(certificatePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load existing certificate %s", rw.baseName)
+	}
+	return certs[0], nil
+}
+
+// Write saves newCert, replacing the current one. newKey may be nil, meaning
+// the private key backing this certificate is unchanged (e.g. it lives in a
+// PKCS#11 token or a cloud KMS and was never read into kubeadm); in that case
+// only the certificate file is (re)written.
+func (rw *pkiCertificateReadWriter) Write(newCert *x509.Certificate, newKey crypto.Signer) error {
+	if newKey == nil {
+		if err := pkiutil.WriteCert(rw.dir, rw.baseName, newCert); err != nil {
+			return errors.Wrapf(err, "failed to write new certificate %s", rw.baseName)
+		}
+		return nil
+	}
+
+	if err := pkiutil.WriteCertAndKey(rw.dir, rw.baseName, newCert, newKey); err != nil {
+		return errors.Wrapf(err, "failed to write new certificate %s", rw.baseName)
+	}
+	return nil
+}
+
+// kubeconfigReadWriter reads and writes the client certificate/key pair
+// embedded in the AuthInfo of a kubeadm-managed kubeconfig file. kubeadm's
+// generated kubeconfig files always carry exactly one AuthInfo entry.
+type kubeconfigReadWriter struct {
+	kubernetesDir   string
+	kubeConfigFile  string
+	certificatesDir string
+	caBaseName      string
+}
+
+func newKubeconfigReadWriter(kubernetesDir, kubeConfigFile, certificatesDir, caBaseName string) *kubeconfigReadWriter {
+	return &kubeconfigReadWriter{
+		kubernetesDir:   kubernetesDir,
+		kubeConfigFile:  kubeConfigFile,
+		certificatesDir: certificatesDir,
+		caBaseName:      caBaseName,
+	}
+}
+
+func (rw *kubeconfigReadWriter) filePath() string {
+	return filepath.Join(rw.kubernetesDir, rw.kubeConfigFile)
+}
+
+func (rw *kubeconfigReadWriter) Exists() bool {
+	_, err := os.Stat(rw.filePath())
+	return err == nil
+}
+
+func (rw *kubeconfigReadWriter) Read() (*x509.Certificate, error) {
+	config, err := clientcmd.LoadFromFile(rw.filePath())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load kubeconfig file %s", rw.kubeConfigFile)
+	}
+
+	authInfo, err := rw.onlyAuthInfo(config)
+	if err != nil {
+		return nil, err
+	}
+
+	certs, err := clientcertutil.ParseCertsPEM(authInfo.ClientCertificateData)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the client certificate embedded in %s", rw.kubeConfigFile)
+	}
+
+	return certs[0], nil
+}
+
+// Write saves newCert, replacing the current one. newKey may be nil, meaning
+// the private key backing this certificate is unchanged; in that case the
+// embedded ClientKeyData is left untouched.
+func (rw *kubeconfigReadWriter) Write(newCert *x509.Certificate, newKey crypto.Signer) error {
+	config, err := clientcmd.LoadFromFile(rw.filePath())
+	if err != nil {
+		return errors.Wrapf(err, "failed to load kubeconfig file %s", rw.kubeConfigFile)
+	}
+
+	authInfo, err := rw.onlyAuthInfo(config)
+	if err != nil {
+		return err
+	}
+
+	caCert, _, err := pkiutil.TryLoadCertAndKeyFromDisk(rw.certificatesDir, rw.caBaseName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load CA certificate %s", rw.caBaseName)
+	}
+
+	authInfo.ClientCertificateData = clientcertutil.EncodeCertPEM(newCert)
+	if newKey != nil {
+		newKeyBytes, err := clientkeyutil.MarshalPrivateKeyToPEM(newKey)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal the new private key for %s", rw.kubeConfigFile)
+		}
+		authInfo.ClientKeyData = newKeyBytes
+	}
+
+	for _, cluster := range config.Clusters {
+		cluster.CertificateAuthorityData = clientcertutil.EncodeCertPEM(caCert)
+	}
+
+	return clientcmd.WriteToFile(*config, rw.filePath())
+}
+
+func (rw *kubeconfigReadWriter) onlyAuthInfo(config *clientcmdapi.Config) (*clientcmdapi.AuthInfo, error) {
+	if len(config.AuthInfos) != 1 {
+		return nil, errors.Errorf("expected exactly one user in %s, got %d", rw.kubeConfigFile, len(config.AuthInfos))
+	}
+	for _, authInfo := range config.AuthInfos {
+		return authInfo, nil
+	}
+	return nil, errors.Errorf("expected exactly one user in %s", rw.kubeConfigFile)
+}
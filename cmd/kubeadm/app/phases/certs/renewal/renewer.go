@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"crypto"
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+
+	certutil "k8s.io/client-go/util/cert"
+
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+)
+
+// Renewer abstracts the backend used to obtain a renewed certificate for a
+// single managed certificate. Manager.RenewUsingLocalCA always uses
+// localCARenewer; Manager.renewerFor selects the backend configured for a
+// given certificate name through RenewalPolicy.
+type Renewer interface {
+	// Renew returns a freshly issued certificate/key pair for the certificate
+	// currently read by handler.readwriter, signed according to the backend's
+	// own policy.
+	Renew(handler *CertificateRenewHandler) (*x509.Certificate, crypto.Signer, error)
+}
+
+// localCARenewer renews a certificate by signing a new key pair with the
+// local CA key/cert pair stored in the kubeadm certificates directory. This
+// is the renewer backing Manager.RenewUsingLocalCA.
+type localCARenewer struct {
+	certificatesDir string
+}
+
+func (r *localCARenewer) Renew(handler *CertificateRenewHandler) (*x509.Certificate, crypto.Signer, error) {
+	cert, err := handler.readwriter.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg := certToConfig(cert)
+	applyOrganizationQuirks(handler, cfg)
+
+	caCert, caKey, err := pkiutil.TryLoadCertAndKeyFromDisk(r.certificatesDir, handler.CABaseName)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "couldn't load CA certificate %s", handler.CABaseName)
+	}
+
+	// Preserve the certificate's current key algorithm across the renewal
+	// instead of silently re-issuing with the default; RenewWithKeyType is the
+	// explicit opt-in to migrate to a different algorithm.
+	encryptionAlgorithm, err := detectEncryptionAlgorithm(cert)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "couldn't determine the key algorithm of %s", handler.Name)
+	}
+
+	return pkiutil.NewCertAndKey(caCert, caKey, &pkiutil.CertConfig{
+		Config:              *cfg,
+		EncryptionAlgorithm: encryptionAlgorithm,
+	})
+}
+
+// csrAPIRenewer stands for a certificate that is signed out-of-band (e.g. by
+// a PKI team). It cannot complete a renewal synchronously and writes nothing
+// itself, so Renew always returns an error directing the caller to
+// CreateRenewCSR, which writes the CSR/key pair to outDir.
+type csrAPIRenewer struct {
+	outDir string
+}
+
+func (r *csrAPIRenewer) Renew(handler *CertificateRenewHandler) (*x509.Certificate, crypto.Signer, error) {
+	return nil, nil, errors.Errorf("%s is configured for out-of-band signing; generate a CSR for it in %s with CreateRenewCSR instead", handler.Name, r.outDir)
+}
+
+// applyOrganizationQuirks carries over the organization-name fix-ups that
+// Manager.RenewUsingLocalCA has always applied for certificates that used to
+// be signed with the wrong organization in older kubeadm releases.
+func applyOrganizationQuirks(handler *CertificateRenewHandler, cfg *certutil.Config) {
+	switch handler.Name {
+	case kubeadmconstants.APIServerEtcdClientCertAndKeyBaseName:
+		cfg.Organization = removeOrganization(cfg.Organization, kubeadmconstants.SystemPrivilegedGroup)
+	case kubeadmconstants.APIServerKubeletClientCertAndKeyBaseName:
+		cfg.Organization = replaceOrganization(cfg.Organization, kubeadmconstants.SystemPrivilegedGroup, kubeadmconstants.ClusterAdminsGroupAndClusterRoleBinding)
+	}
+}
@@ -0,0 +1,68 @@
+//go:build pkcs11
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"crypto"
+
+	"github.com/ThalesIgnite/crypto11"
+	"github.com/pkg/errors"
+)
+
+// pkcs11SignerProvider resolves signers backed by keys held in a PKCS#11
+// token (an HSM), addressed by label.
+//
+// This file is only compiled with -tags=pkcs11: crypto11 requires cgo and a
+// native PKCS#11 module, which is incompatible with kubeadm's default
+// CGO_ENABLED=0, statically-linked build. Operators who need HSM-backed
+// renewal must build kubeadm with the pkcs11 tag (and cgo enabled)
+// themselves; everyone else gets the same static binary as before.
+type pkcs11SignerProvider struct {
+	ctx *crypto11.Context
+	// labels maps a certificate name to the PKCS#11 key label to use.
+	labels map[string]string
+}
+
+// NewPKCS11SignerProvider opens a PKCS#11 session using the given module
+// config and returns a SignerProvider that looks up keys by the label
+// configured for each certificate name in labels.
+func NewPKCS11SignerProvider(cfg *crypto11.Config, labels map[string]string) (SignerProvider, error) {
+	ctx, err := crypto11.Configure(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open PKCS#11 session")
+	}
+	return &pkcs11SignerProvider{ctx: ctx, labels: labels}, nil
+}
+
+func (p *pkcs11SignerProvider) Signer(name string) (crypto.Signer, bool, error) {
+	label, ok := p.labels[name]
+	if !ok {
+		return nil, false, nil
+	}
+
+	signer, err := p.ctx.FindKeyPair(nil, []byte(label))
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "failed to find PKCS#11 key pair labeled %q", label)
+	}
+	if signer == nil {
+		return nil, false, errors.Errorf("no PKCS#11 key pair labeled %q", label)
+	}
+
+	return signer, true, nil
+}
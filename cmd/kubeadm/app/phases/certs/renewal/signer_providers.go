@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"crypto"
+	"crypto/x509"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// KMSClient abstracts the subset of a cloud KMS (AWS KMS, GCP KMS, Azure Key
+// Vault) needed to use a remote key as a crypto.Signer: resolving its public
+// key and asking it to sign a digest.
+type KMSClient interface {
+	// PublicKey returns the DER-encoded SubjectPublicKeyInfo of keyID.
+	PublicKey(keyID string) ([]byte, error)
+
+	// Sign returns the signature of digest produced by keyID, using the given hash.
+	Sign(keyID string, digest []byte, opts crypto.SignerOpts) ([]byte, error)
+}
+
+// kmsSignerProvider resolves signers backed by a cloud KMS key, addressed by
+// key ID. It works with any KMSClient implementation, so the same code path
+// covers AWS KMS, GCP KMS and Azure Key Vault.
+type kmsSignerProvider struct {
+	client KMSClient
+	keyIDs map[string]string
+}
+
+// NewKMSSignerProvider returns a SignerProvider backed by client, resolving
+// the KMS key ID configured for each certificate name in keyIDs.
+func NewKMSSignerProvider(client KMSClient, keyIDs map[string]string) SignerProvider {
+	return &kmsSignerProvider{client: client, keyIDs: keyIDs}
+}
+
+func (p *kmsSignerProvider) Signer(name string) (crypto.Signer, bool, error) {
+	keyID, ok := p.keyIDs[name]
+	if !ok {
+		return nil, false, nil
+	}
+
+	pub, err := parseKMSPublicKey(p.client, keyID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &kmsSigner{client: p.client, keyID: keyID, public: pub}, true, nil
+}
+
+// kmsSigner adapts a single KMS key to crypto.Signer.
+type kmsSigner struct {
+	client KMSClient
+	keyID  string
+	public crypto.PublicKey
+}
+
+func (s *kmsSigner) Public() crypto.PublicKey {
+	return s.public
+}
+
+func (s *kmsSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return s.client.Sign(s.keyID, digest, opts)
+}
+
+func parseKMSPublicKey(client KMSClient, keyID string) (crypto.PublicKey, error) {
+	der, err := client.PublicKey(keyID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch the public key for KMS key %q", keyID)
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the public key for KMS key %q", keyID)
+	}
+
+	return pub, nil
+}
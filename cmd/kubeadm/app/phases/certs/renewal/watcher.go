@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// ReasonCertificateExpiringSoon is the Event reason used when a managed
+	// certificate has crossed into the warning or critical threshold.
+	ReasonCertificateExpiringSoon = "CertificateExpiringSoon"
+
+	// ReasonCertificateExpired is the Event reason used once a managed
+	// certificate's NotAfter has passed.
+	ReasonCertificateExpired = "CertificateExpired"
+
+	// defaultRenotifyInterval bounds how often the same certificate can raise
+	// another Event, so a node watching an EXPIRED cert for days doesn't flood
+	// the event stream.
+	defaultRenotifyInterval = 6 * time.Hour
+)
+
+// ExpirationWatcher periodically checks the expiration of every certificate
+// known to a Manager, publishes metrics for each of them, and - when a
+// threshold is crossed - posts a Kubernetes Event against the local node.
+type ExpirationWatcher struct {
+	manager       *Manager
+	client        kubernetes.Interface
+	recorder      record.EventRecorder
+	nodeRef       *corev1.ObjectReference
+	checkInterval time.Duration
+	renotifyAfter time.Duration
+	mu            sync.Mutex
+	lastNotified  map[string]time.Time
+}
+
+// NewExpirationWatcher builds an ExpirationWatcher. client may be nil, in
+// which case no event recorder is created and the watcher still updates
+// metrics but skips posting Events (e.g. when no kubeconfig is available).
+func NewExpirationWatcher(manager *Manager, client kubernetes.Interface, nodeName string, checkInterval time.Duration) *ExpirationWatcher {
+	w := &ExpirationWatcher{
+		manager:       manager,
+		client:        client,
+		checkInterval: checkInterval,
+		renotifyAfter: defaultRenotifyInterval,
+		lastNotified:  map[string]time.Time{},
+	}
+
+	if client != nil {
+		w.nodeRef = &corev1.ObjectReference{Kind: "Node", Name: nodeName}
+
+		broadcaster := record.NewBroadcaster()
+		broadcaster.StartRecordingToSink(&clientCoreV1EventSink{client})
+		w.recorder = broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "kubeadm-cert-watcher"})
+	}
+
+	return w
+}
+
+// Run checks certificate expiration once immediately and then every
+// checkInterval, until ctx is cancelled.
+func (w *ExpirationWatcher) Run(ctx context.Context, now func() time.Time) {
+	w.runOnce(now())
+
+	ticker := time.NewTicker(w.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.runOnce(now())
+		}
+	}
+}
+
+func (w *ExpirationWatcher) runOnce(now time.Time) {
+	infos, err := w.manager.CheckExpiration(now)
+	if err != nil {
+		klog.Errorf("failed to check certificate expiration: %v", err)
+		return
+	}
+
+	for _, info := range infos {
+		recordExpiration(info, now)
+		w.maybeNotify(info, now)
+	}
+}
+
+func (w *ExpirationWatcher) maybeNotify(info CertExpirationInfo, now time.Time) {
+	if w.recorder == nil || info.Threshold == ExpirationInfo {
+		return
+	}
+
+	w.mu.Lock()
+	last, notified := w.lastNotified[info.Name]
+	shouldNotify := !notified || now.Sub(last) >= w.renotifyAfter
+	if shouldNotify {
+		w.lastNotified[info.Name] = now
+	}
+	w.mu.Unlock()
+
+	if !shouldNotify {
+		return
+	}
+
+	reason := ReasonCertificateExpiringSoon
+	eventType := corev1.EventTypeWarning
+	if info.Threshold == ExpirationExpired {
+		reason = ReasonCertificateExpired
+	}
+
+	w.recorder.Eventf(w.nodeRef, eventType, reason,
+		"certificate %q (subject=%q) is %s: NotAfter=%s",
+		info.Name, info.Subject, info.Threshold, info.NotAfter.Format(time.RFC3339))
+}
+
+type clientCoreV1EventSink struct {
+	client kubernetes.Interface
+}
+
+func (s *clientCoreV1EventSink) Create(event *corev1.Event) (*corev1.Event, error) {
+	return s.client.CoreV1().Events(event.Namespace).Create(context.Background(), event, metav1.CreateOptions{})
+}
+
+func (s *clientCoreV1EventSink) Update(event *corev1.Event) (*corev1.Event, error) {
+	return s.client.CoreV1().Events(event.Namespace).Update(context.Background(), event, metav1.UpdateOptions{})
+}
+
+func (s *clientCoreV1EventSink) Patch(event *corev1.Event, data []byte) (*corev1.Event, error) {
+	return s.client.CoreV1().Events(event.Namespace).Patch(context.Background(), event.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewal
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func newTestWatcher(renotifyAfter time.Duration) (*ExpirationWatcher, *record.FakeRecorder) {
+	recorder := record.NewFakeRecorder(10)
+	w := &ExpirationWatcher{
+		recorder:      recorder,
+		nodeRef:       &corev1.ObjectReference{Kind: "Node", Name: "test-node"},
+		renotifyAfter: renotifyAfter,
+		lastNotified:  map[string]time.Time{},
+	}
+	return w, recorder
+}
+
+func expectEvent(t *testing.T, recorder *record.FakeRecorder, want bool) {
+	t.Helper()
+	select {
+	case <-recorder.Events:
+		if !want {
+			t.Errorf("did not expect an Event to be recorded, but one was")
+		}
+	default:
+		if want {
+			t.Errorf("expected an Event to be recorded, but none was")
+		}
+	}
+}
+
+func TestMaybeNotify(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("INFO threshold never notifies", func(t *testing.T) {
+		w, recorder := newTestWatcher(time.Hour)
+		w.maybeNotify(CertExpirationInfo{Name: "apiserver", Threshold: ExpirationInfo}, now)
+		expectEvent(t, recorder, false)
+	})
+
+	t.Run("first crossing into WARNING notifies", func(t *testing.T) {
+		w, recorder := newTestWatcher(time.Hour)
+		w.maybeNotify(CertExpirationInfo{Name: "apiserver", Threshold: ExpirationWarning}, now)
+		expectEvent(t, recorder, true)
+	})
+
+	t.Run("re-notify is suppressed inside the renotify window", func(t *testing.T) {
+		w, recorder := newTestWatcher(time.Hour)
+		w.maybeNotify(CertExpirationInfo{Name: "apiserver", Threshold: ExpirationWarning}, now)
+		expectEvent(t, recorder, true)
+
+		w.maybeNotify(CertExpirationInfo{Name: "apiserver", Threshold: ExpirationCritical}, now.Add(30*time.Minute))
+		expectEvent(t, recorder, false)
+	})
+
+	t.Run("re-notify fires again once the renotify window elapses", func(t *testing.T) {
+		w, recorder := newTestWatcher(time.Hour)
+		w.maybeNotify(CertExpirationInfo{Name: "apiserver", Threshold: ExpirationWarning}, now)
+		expectEvent(t, recorder, true)
+
+		w.maybeNotify(CertExpirationInfo{Name: "apiserver", Threshold: ExpirationCritical}, now.Add(61*time.Minute))
+		expectEvent(t, recorder, true)
+	})
+
+	t.Run("notifications for different certificates don't share a backoff window", func(t *testing.T) {
+		w, recorder := newTestWatcher(time.Hour)
+		w.maybeNotify(CertExpirationInfo{Name: "apiserver", Threshold: ExpirationWarning}, now)
+		expectEvent(t, recorder, true)
+
+		w.maybeNotify(CertExpirationInfo{Name: "etcd/server", Threshold: ExpirationWarning}, now.Add(time.Minute))
+		expectEvent(t, recorder, true)
+	})
+
+	t.Run("no recorder means no Events, but still no panic", func(t *testing.T) {
+		w := &ExpirationWatcher{renotifyAfter: time.Hour, lastNotified: map[string]time.Time{}}
+		w.maybeNotify(CertExpirationInfo{Name: "apiserver", Threshold: ExpirationExpired}, now)
+	})
+}